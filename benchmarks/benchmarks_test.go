@@ -0,0 +1,257 @@
+package benchmarks_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/storybehind/gocontainer/orderedmap"
+	"github.com/storybehind/gocontainer/orderedset"
+	"github.com/storybehind/gocontainer/orderedset/variants"
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+var sizes = []int{1 << 10, 1 << 16, 1 << 20}
+
+// number of pregenerated keys, cycled through by every benchmark so that generating keys is not measured
+const numKeys = 1 << 16
+
+func less(k1, k2 int) bool {
+	return k1 < k2
+}
+
+// common operations of ordered sets and maps
+type container interface {
+	insert(key int)
+	delete(key int)
+	get(key int) bool
+	deleteMin()
+}
+
+type orderedSet struct {
+	os orderedset.OrderedSet[int]
+}
+
+func (s orderedSet) insert(key int) {
+	s.os.ReplaceOrInsert(key)
+}
+
+func (s orderedSet) delete(key int) {
+	s.os.Delete(key)
+}
+
+func (s orderedSet) get(key int) bool {
+	_, ok := s.os.Get(key)
+	return ok
+}
+
+func (s orderedSet) deleteMin() {
+	s.os.DeleteMin()
+}
+
+type orderedMap struct {
+	om *orderedmap.OrderedMap[int, int]
+}
+
+func (m orderedMap) insert(key int) {
+	m.om.ReplaceOrInsert(key, key)
+}
+
+func (m orderedMap) delete(key int) {
+	m.om.Delete(key)
+}
+
+func (m orderedMap) get(key int) bool {
+	_, ok := m.om.Get(key)
+	return ok
+}
+
+func (m orderedMap) deleteMin() {
+	m.om.DeleteMin()
+}
+
+func subtreeSize(node, sentinel orderedset.BBSTNodeAugmented[int, int]) int {
+	size := 1
+	if node.GetLeftAugmented() != sentinel {
+		size += node.GetLeftAugmented().GetAugmentedValue()
+	}
+	if node.GetRightAugmented() != sentinel {
+		size += node.GetRightAugmented().GetAugmentedValue()
+	}
+	return size
+}
+
+var backends = []struct {
+	name string
+	new  func() container
+}{
+	{"RbTree", func() container { return orderedSet{orderedset.NewRbTree[int](less)} }},
+	{"AvlTree", func() container { return orderedSet{orderedset.NewAvlTree[int](less)} }},
+	{"RbTreeAugmented", func() container { return orderedSet{orderedset.NewRbTreeAugmented[int, int](less, subtreeSize)} }},
+	{"OrderStatisticsTree", func() container { return orderedSet{variants.NewOrderStatisticsTree[int](less)} }},
+	{"OrderedMap-RbTreeTag", func() container { return orderedMap{orderedmap.NewByTag[int, int](less, orderedmap.RbTreeTag)} }},
+	{"OrderedMap-AvlTreeTag", func() container { return orderedMap{orderedmap.NewByTag[int, int](less, orderedmap.AvlTreeTag)} }},
+}
+
+// runs op b.N times for every backend and size, on a container prefilled with keys returned by prefill.
+// op gets keys returned by opKeys, generated before the benchmark starts
+func runMatrix(b *testing.B, prefill func(n int) []int, opKeys func(n int) []int, op func(c container, keys []int, i, n int)) {
+	for _, n := range sizes {
+		keys := prefill(n)
+		var ops []int
+		if opKeys != nil {
+			ops = opKeys(n)
+		}
+		for _, backend := range backends {
+			b.Run(fmt.Sprintf("n=%d/%s", n, backend.name), func(b *testing.B) {
+				c := backend.new()
+				for _, key := range keys {
+					c.insert(key)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					op(c, ops, i, n)
+				}
+			})
+		}
+	}
+}
+
+func sequentialKeys(n int) []int {
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = i
+	}
+	return keys
+}
+
+// returns n random keys in [0, 2n), so that about half of lookups hit
+func randomKeys(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = r.Intn(2 * n)
+	}
+	return keys
+}
+
+// returns numKeys uniformly random keys in [0, 2n)
+func uniformOpKeys(n int) []int {
+	r := rand.New(rand.NewSource(2))
+	keys := make([]int, numKeys)
+	for i := range keys {
+		keys[i] = r.Intn(2 * n)
+	}
+	return keys
+}
+
+// returns numKeys keys in [0, 2n) following Zipf distribution, where small keys are hot
+func zipfOpKeys(n int) []int {
+	z := rand.NewZipf(rand.New(rand.NewSource(2)), 1.2, 1, uint64(2*n-1))
+	keys := make([]int, numKeys)
+	for i := range keys {
+		keys[i] = int(z.Uint64())
+	}
+	return keys
+}
+
+func BenchmarkSequential(b *testing.B) {
+	runMatrix(b, sequentialKeys, nil, func(c container, _ []int, i, n int) {
+		c.insert(n + i)
+		c.deleteMin()
+	})
+}
+
+func BenchmarkRandom(b *testing.B) {
+	runMatrix(b, randomKeys, uniformOpKeys, func(c container, keys []int, i, _ int) {
+		c.insert(keys[i%numKeys])
+		c.delete(keys[(i+numKeys/2)%numKeys])
+	})
+}
+
+func BenchmarkSkewed(b *testing.B) {
+	runMatrix(b, randomKeys, zipfOpKeys, func(c container, keys []int, i, _ int) {
+		key := keys[i%numKeys]
+		if i%10 == 0 {
+			c.insert(key)
+			return
+		}
+		c.get(key)
+	})
+}
+
+// returns op doing lookups in readPercent of cases and otherwise inserting or deleting keys
+func mixed(readPercent int) func(c container, keys []int, i, n int) {
+	return func(c container, keys []int, i, _ int) {
+		key := keys[i%numKeys]
+		switch p := i % 100; {
+		case p < readPercent:
+			c.get(key)
+		case p%2 == 0:
+			c.insert(key)
+		default:
+			c.delete(key)
+		}
+	}
+}
+
+func BenchmarkReadHeavy(b *testing.B) {
+	runMatrix(b, randomKeys, uniformOpKeys, mixed(90))
+}
+
+func BenchmarkWriteHeavy(b *testing.B) {
+	runMatrix(b, randomKeys, uniformOpKeys, mixed(50))
+}
+
+func BenchmarkBinaryHeap(b *testing.B) {
+	workloads := []struct {
+		name string
+		// values pushed by the workload, also used to prefill the heap
+		values func(n int) []int
+		op     func(bh *priorityqueue.BinaryHeap[int], nodes []*priorityqueue.BinaryHeapNode[int], values []int, i, n int)
+	}{
+		{"Sequential", func(n int) []int { return sequentialKeys(numKeys) }, func(bh *priorityqueue.BinaryHeap[int], _ []*priorityqueue.BinaryHeapNode[int], _ []int, i, n int) {
+			bh.Push(n + i)
+			bh.Pop()
+		}},
+		{"Random", uniformOpKeys, func(bh *priorityqueue.BinaryHeap[int], _ []*priorityqueue.BinaryHeapNode[int], values []int, i, _ int) {
+			bh.Push(values[i%numKeys])
+			bh.Pop()
+		}},
+		{"Skewed", zipfOpKeys, func(bh *priorityqueue.BinaryHeap[int], _ []*priorityqueue.BinaryHeapNode[int], values []int, i, _ int) {
+			bh.Push(values[i%numKeys])
+			bh.Pop()
+		}},
+		{"Mixed", uniformOpKeys, func(bh *priorityqueue.BinaryHeap[int], nodes []*priorityqueue.BinaryHeapNode[int], values []int, i, _ int) {
+			// nodes of prefilled values that are popped are left untouched by Update
+			value := values[i%numKeys]
+			switch i % 4 {
+			case 0:
+				bh.Update(nodes[i%len(nodes)], value)
+			case 1:
+				bh.Top()
+			default:
+				bh.Push(value)
+				bh.Pop()
+			}
+		}},
+	}
+	for _, workload := range workloads {
+		for _, n := range sizes {
+			b.Run(fmt.Sprintf("%s/n=%d", workload.name, n), func(b *testing.B) {
+				values := workload.values(n)
+				bh := priorityqueue.NewBinaryHeap[int](less)
+				nodes := make([]*priorityqueue.BinaryHeapNode[int], n)
+				for i := range nodes {
+					nodes[i] = bh.Push(values[i%len(values)])
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					workload.op(bh, nodes, values, i, n)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Package benchmarks compares containers of gocontainer across workloads and sizes. It has no code besides its benchmarks.
+//
+// Ordered sets and maps (RbTree, AvlTree, RbTreeAugmented, OrderStatisticsTree and OrderedMap with both AvlTreeTag and RbTreeTag)
+// run the same workloads on a container prefilled with n keys, keeping its size around n:
+//
+//   - Sequential: inserts keys in ascending order and deletes the minimum key, like a sliding window over timestamps.
+//   - Random: inserts and deletes uniformly random keys.
+//   - Skewed: looks up and replaces keys following a Zipf distribution, so few keys are hot.
+//   - ReadHeavy: 90% lookups of random keys, 10% inserts and deletes.
+//   - WriteHeavy: 50% lookups of random keys, 50% inserts and deletes.
+//
+// BinaryHeap runs comparable Sequential, Random, Skewed and Mixed (pushes, pops, Top and Update of held handles) workloads.
+//
+// Every benchmark reports allocations. Benchmarks of sets and maps are named Benchmark<Workload>/n=<size>/<backend>,
+// and benchmarks of BinaryHeap BenchmarkBinaryHeap/<Workload>/n=<size>. Filter by backend to compare two of them, for example:
+//
+//	go test ./benchmarks -run '^$' -bench 'n=65536/(AvlTree|RbTree)$' -count 10 | tee bench.txt
+//	benchstat bench.txt
+package benchmarks
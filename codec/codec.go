@@ -0,0 +1,291 @@
+// Package codec provides element codecs and a versioned header used by binary encoding of containers.
+package codec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// ErrCorrupt is returned when encoded data is truncated or malformed
+var ErrCorrupt = errors.New("codec: corrupt data")
+
+// Encodes and decodes values of type T
+type Codec[T any] interface {
+	// Appends encoding of value to buf and returns the extended buffer
+	Append(buf []byte, value T) ([]byte, error)
+	// Decodes value from the beginning of buf and returns it with number of bytes read
+	Decode(buf []byte) (T, int, error)
+}
+
+// Returns codec for type T.
+// Types implementing encoding.BinaryMarshaler and encoding.BinaryUnmarshaler (on pointer receiver) use them,
+// booleans, numbers, strings and byte slices (including named types of these kinds) use compact varint based encodings,
+// and every other type is encoded with encoding/gob.
+func Default[T any]() Codec[T] {
+	var zero T
+	if _, ok := any(zero).(encoding.BinaryMarshaler); ok {
+		if _, ok := any(&zero).(encoding.BinaryUnmarshaler); ok {
+			return binaryMarshalerCodec[T]{}
+		}
+	}
+	typ := reflect.TypeOf(&zero).Elem()
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return kindCodec[T]{}
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return kindCodec[T]{}
+		}
+	}
+	return Gob[T]()
+}
+
+// Returns codec encoding every value with encoding/gob, prefixed by its length.
+// Works with any type supported by encoding/gob but is considerably larger and slower than specialized codecs.
+func Gob[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Append(buf []byte, value T) ([]byte, error) {
+	var encoded bytes.Buffer
+	if err := gob.NewEncoder(&encoded).Encode(&value); err != nil {
+		return buf, err
+	}
+	return AppendBytes(buf, encoded.Bytes()), nil
+}
+
+func (gobCodec[T]) Decode(buf []byte) (T, int, error) {
+	var value T
+	encoded, n, err := ReadBytes(buf)
+	if err != nil {
+		return value, 0, err
+	}
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&value); err != nil {
+		return value, 0, err
+	}
+	return value, n, nil
+}
+
+type binaryMarshalerCodec[T any] struct{}
+
+func (binaryMarshalerCodec[T]) Append(buf []byte, value T) ([]byte, error) {
+	encoded, err := any(value).(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return buf, err
+	}
+	return AppendBytes(buf, encoded), nil
+}
+
+func (binaryMarshalerCodec[T]) Decode(buf []byte) (T, int, error) {
+	var value T
+	encoded, n, err := ReadBytes(buf)
+	if err != nil {
+		return value, 0, err
+	}
+	if err := any(&value).(encoding.BinaryUnmarshaler).UnmarshalBinary(encoded); err != nil {
+		return value, 0, err
+	}
+	return value, n, nil
+}
+
+// encodes booleans, numbers, strings and byte slices based on their kind
+type kindCodec[T any] struct{}
+
+func (kindCodec[T]) Append(buf []byte, value T) ([]byte, error) {
+	v := reflect.ValueOf(&value).Elem()
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(buf, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(buf, v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.Float())), nil
+	case reflect.String:
+		return AppendBytes(buf, []byte(v.String())), nil
+	default:
+		return AppendBytes(buf, v.Bytes()), nil
+	}
+}
+
+func (kindCodec[T]) Decode(buf []byte) (T, int, error) {
+	var value T
+	v := reflect.ValueOf(&value).Elem()
+	switch v.Kind() {
+	case reflect.Bool:
+		if len(buf) < 1 || buf[0] > 1 {
+			return value, 0, ErrCorrupt
+		}
+		v.SetBool(buf[0] == 1)
+		return value, 1, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(buf)
+		if n <= 0 || v.OverflowInt(x) {
+			return value, 0, ErrCorrupt
+		}
+		v.SetInt(x)
+		return value, n, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, n := binary.Uvarint(buf)
+		if n <= 0 || v.OverflowUint(x) {
+			return value, 0, ErrCorrupt
+		}
+		v.SetUint(x)
+		return value, n, nil
+	case reflect.Float32, reflect.Float64:
+		if len(buf) < 8 {
+			return value, 0, ErrCorrupt
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(buf)))
+		return value, 8, nil
+	case reflect.String:
+		bytes, n, err := ReadBytes(buf)
+		if err != nil {
+			return value, 0, err
+		}
+		v.SetString(string(bytes))
+		return value, n, nil
+	default:
+		bytes, n, err := ReadBytes(buf)
+		if err != nil {
+			return value, 0, err
+		}
+		v.SetBytes(append([]byte{}, bytes...))
+		return value, n, nil
+	}
+}
+
+// Appends length of b followed by b to buf
+func AppendBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// Reads bytes written by AppendBytes from the beginning of buf. Returned slice aliases buf.
+func ReadBytes(buf []byte) ([]byte, int, error) {
+	length, n := binary.Uvarint(buf)
+	if n <= 0 || length > uint64(len(buf)-n) {
+		return nil, 0, ErrCorrupt
+	}
+	end := n + int(length)
+	return buf[n:end], end, nil
+}
+
+// Kind of container stored in encoded data
+type Kind byte
+
+const (
+	// sorted keys of an ordered set
+	OrderedSetKind Kind = iota + 1
+	// key value pairs of an ordered map sorted by key
+	OrderedMapKind
+	// values of a priority queue
+	PriorityQueueKind
+	// key value pairs of an ordered map sorted by key, written in checksummed chunks
+	OrderedMapStreamKind
+)
+
+// Version of encoding written by AppendHeader
+const Version byte = 1
+
+var magic = [...]byte{'G', 'C', 'N', 'T'}
+
+// Appends header made of magic bytes, Version, kind and length to buf
+func AppendHeader(buf []byte, kind Kind, length int64) []byte {
+	buf = append(buf, magic[:]...)
+	buf = append(buf, Version, byte(kind))
+	return binary.AppendUvarint(buf, uint64(length))
+}
+
+// Reads header written by AppendHeader from the beginning of buf and returns length with number of bytes read.
+// Returns error if buf does not start with a header of given kind or the header is of newer version.
+func ReadHeader(buf []byte, kind Kind) (int64, int, error) {
+	if len(buf) < len(magic)+2 || !bytes.Equal(buf[:len(magic)], magic[:]) {
+		return 0, 0, ErrCorrupt
+	}
+	if version := buf[len(magic)]; version > Version {
+		return 0, 0, fmt.Errorf("codec: unsupported version %d", version)
+	}
+	if found := Kind(buf[len(magic)+1]); found != kind {
+		return 0, 0, fmt.Errorf("codec: found kind %d, expected %d", found, kind)
+	}
+	n := len(magic) + 2
+	length, m := binary.Uvarint(buf[n:])
+	if m <= 0 || length > math.MaxInt64 {
+		return 0, 0, ErrCorrupt
+	}
+	return int64(length), n + m, nil
+}
+
+// Encodes header of given kind followed by values using codec c
+func Marshal[T any](kind Kind, values []T, c Codec[T]) ([]byte, error) {
+	buf := AppendHeader(nil, kind, int64(len(values)))
+	var err error
+	for _, value := range values {
+		if buf, err = c.Append(buf, value); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// Decodes values written by Marshal with same kind and codec
+func Unmarshal[T any](data []byte, kind Kind, c Codec[T]) ([]T, error) {
+	length, n, err := ReadHeader(data, kind)
+	if err != nil {
+		return nil, err
+	}
+	data = data[n:]
+	// length is not trusted for preallocation since data may be corrupt
+	capacity := length
+	if capacity > int64(len(data)) {
+		capacity = int64(len(data))
+	}
+	values := make([]T, 0, capacity)
+	for i := int64(0); i < length; i++ {
+		value, n, err := c.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		data = data[n:]
+	}
+	if len(data) > 0 {
+		return nil, ErrCorrupt
+	}
+	return values, nil
+}
+
+// Same as ReadHeader but reads header from r and returns length
+func ReadHeaderFrom(r io.ByteReader, kind Kind) (int64, error) {
+	buf := make([]byte, 0, len(magic)+2+binary.MaxVarintLen64)
+	for i := 0; i < len(magic)+2; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		buf = append(buf, b)
+	}
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	length64, _, err := ReadHeader(binary.AppendUvarint(buf, length), kind)
+	return length64, err
+}
@@ -0,0 +1,82 @@
+package codec_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/storybehind/gocontainer/codec"
+)
+
+type celsius float64
+
+type point struct {
+	X, Y int
+}
+
+func testRoundTrip[T any](t *testing.T, values []T) {
+	c := codec.Default[T]()
+	data, err := codec.Marshal[T](codec.OrderedSetKind, values, c)
+	if err != nil {
+		t.Fatalf("%T: Marshal() err = %v", values, err)
+	}
+	decoded, err := codec.Unmarshal[T](data, codec.OrderedSetKind, c)
+	if err != nil {
+		t.Fatalf("%T: Unmarshal() err = %v", values, err)
+	}
+	if !reflect.DeepEqual(decoded, values) {
+		t.Errorf("%T: Unmarshal() = %v, exp = %v", values, decoded, values)
+	}
+	// every truncation of data is rejected
+	for i := 0; i < len(data); i++ {
+		if _, err := codec.Unmarshal[T](data[:i], codec.OrderedSetKind, c); err == nil {
+			t.Errorf("%T: Unmarshal() of data truncated to %d bytes; exp error", values, i)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	testRoundTrip(t, []int{0, -1, 1 << 40, -1 << 62})
+	testRoundTrip(t, []int8{-128, 127})
+	testRoundTrip(t, []uint16{0, 65535})
+	testRoundTrip(t, []float64{0, -2.5, 1e300})
+	testRoundTrip(t, []celsius{-40, 36.6})
+	testRoundTrip(t, []float32{1.5})
+	testRoundTrip(t, []bool{true, false})
+	testRoundTrip(t, []string{"", "hello", "wörld"})
+	testRoundTrip(t, [][]byte{{}, {1, 2, 3}})
+	testRoundTrip(t, []point{{1, 2}, {-3, 4}})
+	testRoundTrip(t, []time.Time{time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)})
+}
+
+func TestDecodeOverflow(t *testing.T) {
+	data, _ := codec.Marshal[int](codec.OrderedSetKind, []int{300}, codec.Default[int]())
+	if _, err := codec.Unmarshal[int8](data, codec.OrderedSetKind, codec.Default[int8]()); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("Unmarshal() of 300 as int8 err = %v, exp = %v", err, codec.ErrCorrupt)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	data := codec.AppendHeader(nil, codec.OrderedMapKind, 42)
+	length, n, err := codec.ReadHeader(data, codec.OrderedMapKind)
+	if length != 42 || n != len(data) || err != nil {
+		t.Errorf("ReadHeader() = (%d, %d, %v), exp = (42, %d, nil)", length, n, err, len(data))
+	}
+	if _, _, err := codec.ReadHeader(data, codec.OrderedSetKind); err == nil {
+		t.Errorf("ReadHeader() with different kind; exp error")
+	}
+	newer := append([]byte{}, data...)
+	newer[4] = codec.Version + 1
+	if _, _, err := codec.ReadHeader(newer, codec.OrderedMapKind); err == nil {
+		t.Errorf("ReadHeader() of newer version; exp error")
+	}
+	if _, _, err := codec.ReadHeader([]byte("JUNKDATA"), codec.OrderedMapKind); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("ReadHeader() of junk err = %v, exp = %v", err, codec.ErrCorrupt)
+	}
+	withTrailing, _ := codec.Marshal[int](codec.OrderedSetKind, []int{1}, codec.Default[int]())
+	withTrailing = append(withTrailing, 0)
+	if _, err := codec.Unmarshal[int](withTrailing, codec.OrderedSetKind, codec.Default[int]()); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("Unmarshal() with trailing bytes err = %v, exp = %v", err, codec.ErrCorrupt)
+	}
+}
@@ -0,0 +1,435 @@
+// Package containertest checks containers of gocontainer against simple reference models.
+//
+// Every Check function decodes data into a sequence of operations, two bytes per operation: the first selects the operation
+// and the second is the key (or value) it works on. Operations are applied both to the container and to a reference model,
+// a sorted slice for sets and maps and a sorted slice for heaps, and every result is compared. After each operation
+// the container's Validate method is called to check its structural invariants.
+//
+// data can come from a native fuzz target or from RandomOps for property-based tests:
+//
+//	func FuzzMyTree(f *testing.F) {
+//		f.Add(containertest.RandomOps(rand.New(rand.NewSource(1)), 100))
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			containertest.CheckOrderedSet(t, NewMyTree(), data)
+//		})
+//	}
+package containertest
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/storybehind/gocontainer/orderedmap"
+	"github.com/storybehind/gocontainer/orderedset"
+	"github.com/storybehind/gocontainer/orderedset/variants"
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+// maximum number of operations decoded from data, which keeps single fuzz input fast
+const maxOps = 1 << 12
+
+// RandomOps returns data encoding n random operations accepted by every Check function.
+func RandomOps(r *rand.Rand, n int) []byte {
+	data := make([]byte, 2*n)
+	r.Read(data)
+	return data
+}
+
+type validator interface {
+	Validate() error
+}
+
+// calls apply for every operation encoded in data and validates container after each of them
+func run(t testing.TB, container validator, data []byte, apply func(step int, op byte, key int)) {
+	t.Helper()
+	for step := 0; step < len(data)/2 && step < maxOps; step++ {
+		apply(step, data[2*step], int(data[2*step+1]))
+		if err := container.Validate(); err != nil {
+			t.Fatalf("step %d: Validate() err = %v", step, err)
+		}
+	}
+}
+
+// sorted slice of unique keys used as reference model of sets and maps
+type sortedModel []int
+
+func (m sortedModel) search(key int) (int, bool) {
+	i := sort.SearchInts(m, key)
+	return i, i < len(m) && m[i] == key
+}
+
+func (m *sortedModel) insert(key int) bool {
+	i, found := m.search(key)
+	if found {
+		return false
+	}
+	*m = append(*m, 0)
+	copy((*m)[i+1:], (*m)[i:])
+	(*m)[i] = key
+	return true
+}
+
+func (m *sortedModel) delete(key int) bool {
+	i, found := m.search(key)
+	if !found {
+		return false
+	}
+	*m = append((*m)[:i], (*m)[i+1:]...)
+	return true
+}
+
+// returns index of key found by one of neighbour queries, or -1 if there is no such key
+func (m sortedModel) neighbour(op byte, key int) int {
+	i, found := m.search(key)
+	switch op {
+	case opGet:
+		if !found {
+			return -1
+		}
+	case opGetGreater:
+		if found {
+			i++
+		}
+	case opGetGreaterThanOrEqual:
+	case opGetLower:
+		i--
+	case opGetLowerThanOrEqual:
+		if !found {
+			i--
+		}
+	}
+	if i < 0 || i >= len(m) {
+		return -1
+	}
+	return i
+}
+
+const (
+	opReplaceOrInsert byte = iota
+	opDelete
+	opDeleteMin
+	opDeleteMax
+	opGet
+	opGetGreater
+	opGetGreaterThanOrEqual
+	opGetLower
+	opGetLowerThanOrEqual
+	opMinMax
+	opIteratorRemove
+	opReverseIteratorRemove
+	numSetOps
+)
+
+// CheckOrderedSet applies operations encoded in data to set and checks results of every OrderedSetI method against a sorted slice.
+// set must be empty and order int keys ascending. If set implements Validate() error, it is called after every operation.
+func CheckOrderedSet(t testing.TB, set orderedset.OrderedSetI[int], data []byte) {
+	t.Helper()
+	var model sortedModel
+	run(t, validatorOf(set), data, func(step int, op byte, key int) {
+		t.Helper()
+		applySetOp(t, set, &model, step, op%numSetOps, key)
+	})
+	checkSetKeys(t, set, model)
+}
+
+// CheckOrderStatisticsTree is like CheckOrderedSet and additionally checks Rank and Select.
+func CheckOrderStatisticsTree(t testing.TB, ost *variants.OrderStatisticsTree[int], data []byte) {
+	t.Helper()
+	var model sortedModel
+	run(t, ost, data, func(step int, op byte, key int) {
+		t.Helper()
+		op %= numSetOps + 2
+		switch op {
+		case numSetOps:
+			i, found := model.search(key)
+			if !found {
+				i = -1
+			}
+			if rank := ost.Rank(key); rank != int64(i) {
+				t.Fatalf("step %d: Rank(%d) = %d, exp = %d", step, key, rank, i)
+			}
+		case numSetOps + 1:
+			got, ok := ost.Select(int64(key))
+			if expOk := key < len(model); ok != expOk || (ok && got != model[key]) {
+				t.Fatalf("step %d: Select(%d) = (%d, %v), exp found = %v", step, key, got, ok, expOk)
+			}
+		default:
+			applySetOp(t, ost, &model, step, op, key)
+		}
+	})
+	checkSetKeys(t, ost, model)
+}
+
+func validatorOf(set any) validator {
+	if v, ok := set.(validator); ok {
+		return v
+	}
+	return noValidator{}
+}
+
+type noValidator struct{}
+
+func (noValidator) Validate() error {
+	return nil
+}
+
+func applySetOp(t testing.TB, set orderedset.OrderedSetI[int], model *sortedModel, step int, op byte, key int) {
+	t.Helper()
+	check := func(name string, got int, ok bool, expIndex int) {
+		t.Helper()
+		if ok != (expIndex >= 0) || (ok && got != (*model)[expIndex]) {
+			t.Fatalf("step %d: %s(%d) = (%d, %v), exp found = %v", step, name, key, got, ok, expIndex >= 0)
+		}
+	}
+	switch op {
+	case opReplaceOrInsert:
+		_, replaced := set.ReplaceOrInsert(key)
+		if inserted := model.insert(key); replaced == inserted {
+			t.Fatalf("step %d: ReplaceOrInsert(%d) replaced = %v, exp = %v", step, key, replaced, !inserted)
+		}
+	case opDelete:
+		got, ok := set.Delete(key)
+		if deleted := model.delete(key); ok != deleted || (ok && got != key) {
+			t.Fatalf("step %d: Delete(%d) = (%d, %v), exp found = %v", step, key, got, ok, deleted)
+		}
+	case opDeleteMin, opDeleteMax:
+		name, deleteFunc, expIndex := "DeleteMin", set.DeleteMin, 0
+		if op == opDeleteMax {
+			name, deleteFunc, expIndex = "DeleteMax", set.DeleteMax, len(*model)-1
+		}
+		if len(*model) == 0 {
+			expIndex = -1
+		}
+		got, ok := deleteFunc()
+		check(name, got, ok, expIndex)
+		if ok {
+			model.delete(got)
+		}
+	case opGet, opGetGreater, opGetGreaterThanOrEqual, opGetLower, opGetLowerThanOrEqual:
+		queries := map[byte]struct {
+			name  string
+			query func(key int) (int, bool)
+		}{
+			opGet:                   {"Get", set.Get},
+			opGetGreater:            {"GetGreater", set.GetGreater},
+			opGetGreaterThanOrEqual: {"GetGreaterThanOrEqual", set.GetGreaterThanOrEqual},
+			opGetLower:              {"GetLower", set.GetLower},
+			opGetLowerThanOrEqual:   {"GetLowerThanOrEqual", set.GetLowerThanOrEqual},
+		}
+		got, ok := queries[op].query(key)
+		check(queries[op].name, got, ok, model.neighbour(op, key))
+	case opMinMax:
+		minIndex, maxIndex := 0, len(*model)-1
+		if len(*model) == 0 {
+			minIndex = -1
+		}
+		got, ok := set.Min()
+		check("Min", got, ok, minIndex)
+		got, ok = set.Max()
+		check("Max", got, ok, maxIndex)
+	case opIteratorRemove:
+		// removes smallest key greater than or equal to key through forward iterator
+		i := model.neighbour(opGetGreaterThanOrEqual, key)
+		if i < 0 {
+			return
+		}
+		iterator := set.Begin()
+		for k, ok := iterator.Key(); !ok || k != (*model)[i]; k, ok = iterator.Next() {
+			if !ok {
+				t.Fatalf("step %d: forward iteration did not reach key %d", step, (*model)[i])
+			}
+		}
+		got, ok := iterator.Remove()
+		*model = append((*model)[:i], (*model)[i+1:]...)
+		if i == len(*model) {
+			i = -1
+		}
+		check("Begin().Remove", got, ok, i)
+	case opReverseIteratorRemove:
+		// removes greatest key lower than or equal to key through reverse iterator
+		i := model.neighbour(opGetLowerThanOrEqual, key)
+		if i < 0 {
+			return
+		}
+		iterator := set.Rbegin()
+		for k, ok := iterator.Key(); !ok || k != (*model)[i]; k, ok = iterator.Prev() {
+			if !ok {
+				t.Fatalf("step %d: reverse iteration did not reach key %d", step, (*model)[i])
+			}
+		}
+		got, ok := iterator.Remove()
+		*model = append((*model)[:i], (*model)[i+1:]...)
+		check("Rbegin().Remove", got, ok, i-1)
+	}
+	if set.Len() != int64(len(*model)) {
+		t.Fatalf("step %d: Len() = %d, exp = %d", step, set.Len(), len(*model))
+	}
+}
+
+// checks that set iterates exactly keys of model in both directions
+func checkSetKeys(t testing.TB, set orderedset.OrderedSetI[int], model sortedModel) {
+	t.Helper()
+	iterator := set.Begin()
+	for _, exp := range model {
+		if key, ok := iterator.Key(); !ok || key != exp {
+			t.Fatalf("forward iteration: Key() = (%d, %v), exp = %d", key, ok, exp)
+		}
+		iterator.Next()
+	}
+	if key, ok := iterator.Key(); ok {
+		t.Fatalf("forward iteration: unexpected key %d after last key", key)
+	}
+	reverseIterator := set.Rbegin()
+	for i := len(model) - 1; i >= 0; i-- {
+		if key, ok := reverseIterator.Key(); !ok || key != model[i] {
+			t.Fatalf("reverse iteration: Key() = (%d, %v), exp = %d", key, ok, model[i])
+		}
+		reverseIterator.Prev()
+	}
+	if key, ok := reverseIterator.Key(); ok {
+		t.Fatalf("reverse iteration: unexpected key %d after smallest key", key)
+	}
+}
+
+// CheckOrderedMap applies operations encoded in data to m and checks results against a sorted slice of keys and a map of values.
+// m must be empty and order int keys ascending. Value stored with a key is the step of operation that stored it.
+func CheckOrderedMap(t testing.TB, m *orderedmap.OrderedMap[int, int], data []byte) {
+	t.Helper()
+	var keys sortedModel
+	values := make(map[int]int)
+	checkPair := func(step int, name string, key int, pair orderedmap.KeyValuePair[int, int], ok bool, expIndex int) {
+		t.Helper()
+		if ok != (expIndex >= 0) {
+			t.Fatalf("step %d: %s(%d) found = %v, exp = %v", step, name, key, ok, expIndex >= 0)
+		}
+		if ok && (pair.GetKey() != keys[expIndex] || pair.GetValue() != values[keys[expIndex]]) {
+			t.Fatalf("step %d: %s(%d) = %v, exp = %d:%d", step, name, key, pair, keys[expIndex], values[keys[expIndex]])
+		}
+	}
+	run(t, m, data, func(step int, op byte, key int) {
+		t.Helper()
+		switch op % 6 {
+		case 0, 1:
+			pair, replaced := m.ReplaceOrInsert(key, step)
+			oldValue, exists := values[key]
+			if replaced != exists || (replaced && pair.GetValue() != oldValue) {
+				t.Fatalf("step %d: ReplaceOrInsert(%d) = (%v, %v), exp replaced = %v", step, key, pair, replaced, exists)
+			}
+			keys.insert(key)
+			values[key] = step
+		case 2:
+			i, found := keys.search(key)
+			if !found {
+				i = -1
+			}
+			pair, ok := m.Delete(key)
+			checkPair(step, "Delete", key, pair, ok, i)
+			keys.delete(key)
+			delete(values, key)
+		case 3:
+			pair, ok := m.Get(key)
+			checkPair(step, "Get", key, pair, ok, keys.neighbour(opGet, key))
+		case 4:
+			pair, ok := m.GetGreaterThanOrEqual(key)
+			checkPair(step, "GetGreaterThanOrEqual", key, pair, ok, keys.neighbour(opGetGreaterThanOrEqual, key))
+			pair, ok = m.GetLower(key)
+			checkPair(step, "GetLower", key, pair, ok, keys.neighbour(opGetLower, key))
+		case 5:
+			i := 0
+			if len(keys) == 0 {
+				i = -1
+			}
+			pair, ok := m.DeleteMin()
+			checkPair(step, "DeleteMin", key, pair, ok, i)
+			if ok {
+				delete(values, keys[0])
+				keys = keys[1:]
+			}
+		}
+		if m.Len() != int64(len(keys)) {
+			t.Fatalf("step %d: Len() = %d, exp = %d", step, m.Len(), len(keys))
+		}
+	})
+	iterator := m.Begin()
+	for _, key := range keys {
+		if pair, ok := iterator.Key(); !ok || pair.GetKey() != key || pair.GetValue() != values[key] {
+			t.Fatalf("iteration: Key() = (%v, %v), exp = %d:%d", pair, ok, key, values[key])
+		}
+		iterator.Next()
+	}
+	if pair, ok := iterator.Key(); ok {
+		t.Fatalf("iteration: unexpected pair %v after last key", pair)
+	}
+}
+
+// CheckBinaryHeap applies operations encoded in data to bh and checks results against a sorted slice of values.
+// bh must be empty and give higher priority to smaller values. Handles returned by Push are kept to exercise Update and Remove.
+func CheckBinaryHeap(t testing.TB, bh *priorityqueue.BinaryHeap[int], data []byte) {
+	t.Helper()
+	var nodes []*priorityqueue.BinaryHeapNode[int]
+	// values in heap in ascending order, may contain duplicates
+	var model []int
+	insertValue := func(value int) {
+		i := sort.SearchInts(model, value)
+		model = append(model, 0)
+		copy(model[i+1:], model[i:])
+		model[i] = value
+	}
+	deleteValue := func(value int) {
+		i := sort.SearchInts(model, value)
+		model = append(model[:i], model[i+1:]...)
+	}
+	run(t, bh, data, func(step int, op byte, value int) {
+		t.Helper()
+		switch op % 6 {
+		case 0, 1:
+			nodes = append(nodes, bh.Push(value))
+			insertValue(value)
+		case 2:
+			got, ok := bh.TryPop()
+			if ok != (len(model) > 0) || (ok && got != model[0]) {
+				t.Fatalf("step %d: TryPop() = (%d, %v), exp = %v", step, got, ok, model)
+			}
+			if ok {
+				model = model[1:]
+			}
+		case 3, 4:
+			if len(nodes) == 0 {
+				return
+			}
+			i := value % len(nodes)
+			node := nodes[i]
+			if !bh.Contains(node) {
+				// popped earlier
+				nodes = append(nodes[:i], nodes[i+1:]...)
+				return
+			}
+			oldValue := node.GetValue()
+			if op%6 == 3 {
+				bh.Update(node, value)
+				deleteValue(oldValue)
+				insertValue(value)
+				return
+			}
+			if got := bh.Remove(node); got != oldValue {
+				t.Fatalf("step %d: Remove() = %d, exp = %d", step, got, oldValue)
+			}
+			deleteValue(oldValue)
+			nodes = append(nodes[:i], nodes[i+1:]...)
+		case 5:
+			node, ok := bh.TryTop()
+			if ok != (len(model) > 0) || (ok && node.GetValue() != model[0]) {
+				t.Fatalf("step %d: TryTop() found = %v, exp = %v", step, ok, model)
+			}
+		}
+		if bh.Len() != int64(len(model)) {
+			t.Fatalf("step %d: Len() = %d, exp = %d", step, bh.Len(), len(model))
+		}
+	})
+	for _, exp := range model {
+		if got := bh.Pop(); got != exp {
+			t.Fatalf("draining heap: Pop() = %d, exp = %d", got, exp)
+		}
+	}
+}
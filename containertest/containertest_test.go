@@ -0,0 +1,142 @@
+package containertest_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/storybehind/gocontainer/containertest"
+	"github.com/storybehind/gocontainer/orderedmap"
+	"github.com/storybehind/gocontainer/orderedset"
+	"github.com/storybehind/gocontainer/orderedset/variants"
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+func less(k1, k2 int) bool {
+	return k1 < k2
+}
+
+func countAugmentValue(node, sentinel orderedset.BBSTNodeAugmented[int, int]) int {
+	count := 1
+	if node.GetLeftAugmented() != sentinel {
+		count += node.GetLeftAugmented().GetAugmentedValue()
+	}
+	if node.GetRightAugmented() != sentinel {
+		count += node.GetRightAugmented().GetAugmentedValue()
+	}
+	return count
+}
+
+func addSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 1, 2, 2, 0, 3, 0})
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{10, 100, 1000} {
+		f.Add(containertest.RandomOps(r, n))
+	}
+}
+
+func FuzzRbTree(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		containertest.CheckOrderedSet(t, orderedset.NewRbTree[int](less), data)
+	})
+}
+
+func FuzzAvlTree(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		containertest.CheckOrderedSet(t, orderedset.NewAvlTree[int](less), data)
+	})
+}
+
+func FuzzRbTreeAugmented(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		containertest.CheckOrderedSet(t, orderedset.NewRbTreeAugmented[int, int](less, countAugmentValue), data)
+	})
+}
+
+func FuzzOrderStatisticsTree(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		containertest.CheckOrderStatisticsTree(t, variants.NewOrderStatisticsTree[int](less), data)
+	})
+}
+
+func FuzzOrderedMap(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		containertest.CheckOrderedMap(t, orderedmap.NewByTag[int, int](less, orderedmap.RbTreeTag), data)
+		containertest.CheckOrderedMap(t, orderedmap.NewByTag[int, int](less, orderedmap.AvlTreeTag), data)
+	})
+}
+
+func FuzzBinaryHeap(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		containertest.CheckBinaryHeap(t, priorityqueue.NewBinaryHeap[int](less), data)
+		containertest.CheckBinaryHeap(t, priorityqueue.NewDaryHeap[int](less, 4), data)
+		containertest.CheckBinaryHeap(t, priorityqueue.NewStableBinaryHeap[int](less), data)
+	})
+}
+
+// property-based run over many random operation sequences
+func TestRandomOps(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		data := containertest.RandomOps(r, 300)
+		containertest.CheckOrderedSet(t, orderedset.NewRbTree[int](less), data)
+		containertest.CheckOrderedSet(t, orderedset.NewAvlTree[int](less), data)
+		containertest.CheckOrderStatisticsTree(t, variants.NewOrderStatisticsTree[int](less), data)
+		containertest.CheckOrderedMap(t, orderedmap.New[int, int](less), data)
+		containertest.CheckBinaryHeap(t, priorityqueue.NewBinaryHeap[int](less), data)
+	}
+}
+
+// set whose iterators have lost every key while Len still counts them
+type lossySet struct {
+	*orderedset.RbTree[int]
+}
+
+func (s lossySet) Begin() orderedset.OrderedSetForwardIterator[int] {
+	return orderedset.NewRbTree[int](less).Begin()
+}
+
+func (s lossySet) Rbegin() orderedset.OrderedSetReverseIterator[int] {
+	return orderedset.NewRbTree[int](less).Rbegin()
+}
+
+// records first fatal failure and stops the goroutine calling Fatalf
+type fatalRecorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.failure = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func TestCheckOrderedSetLostKey(t *testing.T) {
+	for name, data := range map[string][]byte{
+		// ReplaceOrInsert(5), then remove key >= 0 through forward iterator
+		"forward": {0, 5, 10, 0},
+		// ReplaceOrInsert(5), then remove key <= 9 through reverse iterator
+		"reverse": {0, 5, 11, 9},
+	} {
+		recorder := &fatalRecorder{TB: t}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			containertest.CheckOrderedSet(recorder, lossySet{orderedset.NewRbTree[int](less)}, data)
+		}()
+		<-done
+		if exp := fmt.Sprintf("step 1: %s iteration did not reach key 5", name); recorder.failure != exp {
+			t.Errorf("%s: failure = %q, exp = %q", name, recorder.failure, exp)
+		}
+	}
+}
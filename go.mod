@@ -1,4 +1,4 @@
 module github.com/storybehind/gocontainer
 
-go 1.20
+go 1.21
 
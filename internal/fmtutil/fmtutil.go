@@ -0,0 +1,45 @@
+// Package fmtutil implements formatting shared by containers.
+package fmtutil
+
+import (
+	"fmt"
+	"io"
+)
+
+// Maximum number of elements printed when precision is not given
+const DefaultLimit = 100
+
+// Returns maximum number of elements to print. Precision (for example %.10v) overrides DefaultLimit.
+func Limit(state fmt.State) int {
+	if precision, ok := state.Precision(); ok {
+		return precision
+	}
+	return DefaultLimit
+}
+
+// Writes open, at most limit elements separated by spaces and close. writeElement writes next element and returns false when no element remains.
+// If length exceeds limit, number of omitted elements is written before close.
+func WriteSeq(w io.Writer, open, close string, length int64, limit int, writeElement func(w io.Writer) bool) {
+	io.WriteString(w, open)
+	written := 0
+	for ; written < limit && int64(written) < length; written++ {
+		if written > 0 {
+			io.WriteString(w, " ")
+		}
+		if !writeElement(w) {
+			break
+		}
+	}
+	if omitted := length - int64(written); omitted > 0 {
+		if written > 0 {
+			io.WriteString(w, " ")
+		}
+		fmt.Fprintf(w, "...(%d more)", omitted)
+	}
+	io.WriteString(w, close)
+}
+
+// Writes representation of unsupported verb like fmt does, for example %!d(*orderedset.RbTree[int])
+func WriteBadVerb(w io.Writer, verb rune, value any) {
+	fmt.Fprintf(w, "%%!%c(%T)", verb, value)
+}
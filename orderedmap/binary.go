@@ -0,0 +1,95 @@
+package orderedmap
+
+import (
+	"errors"
+
+	"github.com/storybehind/gocontainer/codec"
+)
+
+// Returns codec of KeyValuePair encoding key with keyCodec followed by value with valueCodec
+func KeyValuePairCodec[K, V any](keyCodec codec.Codec[K], valueCodec codec.Codec[V]) codec.Codec[KeyValuePair[K, V]] {
+	return keyValuePairCodec[K, V]{
+		keyCodec:   keyCodec,
+		valueCodec: valueCodec,
+	}
+}
+
+type keyValuePairCodec[K, V any] struct {
+	keyCodec   codec.Codec[K]
+	valueCodec codec.Codec[V]
+}
+
+func (kvc keyValuePairCodec[K, V]) Append(buf []byte, kvpair KeyValuePair[K, V]) ([]byte, error) {
+	buf, err := kvc.keyCodec.Append(buf, kvpair.key)
+	if err != nil {
+		return buf, err
+	}
+	return kvc.valueCodec.Append(buf, kvpair.value)
+}
+
+func (kvc keyValuePairCodec[K, V]) Decode(buf []byte) (KeyValuePair[K, V], int, error) {
+	var kvpair KeyValuePair[K, V]
+	key, n, err := kvc.keyCodec.Decode(buf)
+	if err != nil {
+		return kvpair, 0, err
+	}
+	value, m, err := kvc.valueCodec.Decode(buf[n:])
+	if err != nil {
+		return kvpair, 0, err
+	}
+	kvpair.key = key
+	kvpair.value = value
+	return kvpair, n + m, nil
+}
+
+// Sets codecs of keys and values used by MarshalBinary and UnmarshalBinary. nil codec means codec.Default is used, which is also the default.
+func (om *OrderedMap[K, V]) SetCodecs(keyCodec codec.Codec[K], valueCodec codec.Codec[V]) {
+	om.keyCodec = keyCodec
+	om.valueCodec = valueCodec
+}
+
+// Encodes the map as versioned header followed by key value pairs in ascending order of keys. Implements encoding.BinaryMarshaler.
+func (om *OrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	pairs := make([]KeyValuePair[K, V], 0, om.Len())
+	iterator := om.Begin()
+	for pair, ok := iterator.Key(); ok; pair, ok = iterator.Next() {
+		pairs = append(pairs, pair)
+	}
+	return codec.Marshal[KeyValuePair[K, V]](codec.OrderedMapKind, pairs, om.pairCodec())
+}
+
+// Decodes data written by MarshalBinary, replacing contents of the map in O(n) time after decoding.
+// Implements encoding.BinaryUnmarshaler. Map must be created by New or NewByTag since less function cannot be decoded.
+func (om *OrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	if om.os == nil {
+		return errors.New("orderedmap: UnmarshalBinary on map not created by constructor")
+	}
+	pairs, err := codec.Unmarshal[KeyValuePair[K, V]](data, codec.OrderedMapKind, om.pairCodec())
+	if err != nil {
+		return err
+	}
+	return om.os.(interface {
+		ReplaceAllSorted(keys []KeyValuePair[K, V]) error
+	}).ReplaceAllSorted(pairs)
+}
+
+// Same as MarshalBinary. Implements gob.GobEncoder.
+func (om *OrderedMap[K, V]) GobEncode() ([]byte, error) {
+	return om.MarshalBinary()
+}
+
+// Same as UnmarshalBinary. Implements gob.GobDecoder.
+func (om *OrderedMap[K, V]) GobDecode(data []byte) error {
+	return om.UnmarshalBinary(data)
+}
+
+func (om *OrderedMap[K, V]) pairCodec() codec.Codec[KeyValuePair[K, V]] {
+	keyCodec, valueCodec := om.keyCodec, om.valueCodec
+	if keyCodec == nil {
+		keyCodec = codec.Default[K]()
+	}
+	if valueCodec == nil {
+		valueCodec = codec.Default[V]()
+	}
+	return KeyValuePairCodec[K, V](keyCodec, valueCodec)
+}
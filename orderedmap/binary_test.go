@@ -0,0 +1,66 @@
+package orderedmap_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/storybehind/gocontainer/codec"
+	"github.com/storybehind/gocontainer/orderedmap"
+)
+
+type coordinates struct {
+	Lat, Lon float64
+}
+
+func TestOrderedMapBinary(t *testing.T) {
+	less := func(k1, k2 string) bool { return k1 < k2 }
+	om := orderedmap.New[string, coordinates](less)
+	om.ReplaceOrInsert("oslo", coordinates{59.9, 10.7})
+	om.ReplaceOrInsert("berlin", coordinates{52.5, 13.4})
+	om.ReplaceOrInsert("lima", coordinates{-12, -77})
+	data, err := om.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() err = %v", err)
+	}
+
+	for _, tag := range []orderedmap.Tag{orderedmap.AvlTreeTag, orderedmap.RbTreeTag} {
+		decoded := orderedmap.NewByTag[string, coordinates](less, tag)
+		decoded.ReplaceOrInsert("paris", coordinates{48.9, 2.4})
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary() err = %v", err)
+		}
+		if _, ok := decoded.Get("paris"); ok || decoded.Len() != 3 {
+			t.Errorf("UnmarshalBinary() should replace contents of the map")
+		}
+		if pair, ok := decoded.Get("lima"); !ok || pair.GetValue() != (coordinates{-12, -77}) {
+			t.Errorf("Get(lima) = (%v, %v), exp = ({-12 -77}, true)", pair.GetValue(), ok)
+		}
+	}
+
+	// decoding with keys in different order fails since bulk build requires sorted keys
+	reversed := orderedmap.New[string, coordinates](func(k1, k2 string) bool { return k1 > k2 })
+	if err := reversed.UnmarshalBinary(data); err == nil {
+		t.Errorf("UnmarshalBinary() into map with different order; exp error")
+	}
+}
+
+func TestOrderedMapGob(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	om := orderedmap.New[int, string](less)
+	om.SetCodecs(codec.Gob[int](), nil)
+	om.ReplaceOrInsert(2, "two")
+	om.ReplaceOrInsert(1, "one")
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(om); err != nil {
+		t.Fatalf("Encode() err = %v", err)
+	}
+	decoded := orderedmap.New[int, string](less)
+	decoded.SetCodecs(codec.Gob[int](), nil)
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatalf("Decode() err = %v", err)
+	}
+	if pair, ok := decoded.Min(); !ok || pair.GetKey() != 1 || pair.GetValue() != "one" {
+		t.Errorf("Min() = (%d, %s), exp = (1, one)", pair.GetKey(), pair.GetValue())
+	}
+}
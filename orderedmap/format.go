@@ -0,0 +1,55 @@
+package orderedmap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/storybehind/gocontainer/internal/fmtutil"
+)
+
+// Returns pair like key:value
+func (kvpair KeyValuePair[K, V]) String() string {
+	return fmt.Sprint(kvpair)
+}
+
+// Implements fmt.Formatter. %v and %s print pair like key:value and %+v prints it like {key:key value:value}.
+func (kvpair KeyValuePair[K, V]) Format(state fmt.State, verb rune) {
+	if verb != 'v' && verb != 's' {
+		fmtutil.WriteBadVerb(state, verb, kvpair)
+		return
+	}
+	if state.Flag('+') {
+		fmt.Fprintf(state, "{key:%v value:%v}", kvpair.key, kvpair.value)
+		return
+	}
+	fmt.Fprintf(state, "%v:%v", kvpair.key, kvpair.value)
+}
+
+// Returns pairs in ascending order of keys like map[k1:v1 k2:v2], truncated after fmtutil.DefaultLimit pairs
+func (om *OrderedMap[K, V]) String() string {
+	return fmt.Sprint(om)
+}
+
+// Implements fmt.Formatter. %v and %s print pairs in ascending order of keys like map[k1:v1 k2:v2].
+// %+v prints structure of underlying tree, where every node's key is printed as key:value.
+// Precision limits number of printed pairs (or nodes), for example %.10v. By default, at most fmtutil.DefaultLimit pairs are printed.
+func (om *OrderedMap[K, V]) Format(state fmt.State, verb rune) {
+	if verb != 'v' && verb != 's' {
+		fmtutil.WriteBadVerb(state, verb, om)
+		return
+	}
+	limit := fmtutil.Limit(state)
+	if state.Flag('+') {
+		fmt.Fprintf(state, "%+.*v", limit, om.os)
+		return
+	}
+	iterator := om.Begin()
+	fmtutil.WriteSeq(state, "map[", "]", om.Len(), limit, func(w io.Writer) bool {
+		pair, ok := iterator.Key()
+		if ok {
+			fmt.Fprint(w, pair)
+			iterator.Next()
+		}
+		return ok
+	})
+}
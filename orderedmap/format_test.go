@@ -0,0 +1,34 @@
+package orderedmap_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/storybehind/gocontainer/orderedmap"
+)
+
+func TestOrderedMapFormat(t *testing.T) {
+	om := orderedmap.New[string, int](func(k1, k2 string) bool { return k1 < k2 })
+	if s := om.String(); s != "map[]" {
+		t.Errorf("String() of empty map = %s, exp = map[]", s)
+	}
+	om.ReplaceOrInsert("b", 2)
+	om.ReplaceOrInsert("a", 1)
+	om.ReplaceOrInsert("c", 3)
+	tests := []struct {
+		format string
+		value  any
+		exp    string
+	}{
+		{"%v", om, "map[a:1 b:2 c:3]"},
+		{"%.1v", om, "map[a:1 ...(2 more)]"},
+		{"%+v", om, "(b:2 black (a:1 red) (c:3 red))"},
+		{"%v", orderedmap.NewKeyValuePair[string, int]("a", 1), "a:1"},
+		{"%+v", orderedmap.NewKeyValuePair[string, int]("a", 1), "{key:a value:1}"},
+	}
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, test.value); s != test.exp {
+			t.Errorf("Sprintf(%s) = %s, exp = %s", test.format, s, test.exp)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var errUnmarshalUninitialized = errors.New("orderedmap: UnmarshalJSON on map not created by constructor")
+
+type jsonKeyValuePair[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// Encodes pair as JSON object {"key": key, "value": value}. Implements json.Marshaler.
+func (kvpair KeyValuePair[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonKeyValuePair[K, V]{Key: kvpair.key, Value: kvpair.value})
+}
+
+// Decodes JSON object {"key": key, "value": value} into pair. Implements json.Unmarshaler.
+func (kvpair *KeyValuePair[K, V]) UnmarshalJSON(data []byte) error {
+	var pair jsonKeyValuePair[K, V]
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	kvpair.key = pair.Key
+	kvpair.value = pair.Value
+	return nil
+}
+
+// Implements json.Marshaler. If K is of string kind or implements encoding.TextMarshaler, the map is encoded as JSON object with keys in ascending order.
+// Otherwise, it is encoded as JSON array of {"key": key, "value": value} objects in ascending order of keys.
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if !isTextKey[K]() {
+		pairs := make([]KeyValuePair[K, V], 0, om.Len())
+		iterator := om.Begin()
+		for pair, ok := iterator.Key(); ok; pair, ok = iterator.Next() {
+			pairs = append(pairs, pair)
+		}
+		return json.Marshal(pairs)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	iterator := om.Begin()
+	for pair, ok := iterator.Key(); ok; pair, ok = iterator.Next() {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, err := marshalTextKey(pair.key)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(pair.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Implements json.Unmarshaler. Accepts both encodings produced by MarshalJSON and replaces contents of the map.
+// JSON object is accepted only if K is of string kind or *K implements encoding.TextUnmarshaler.
+// Map must be created by New or NewByTag since less function cannot be decoded.
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if om.os == nil {
+		return errUnmarshalUninitialized
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var pairs []KeyValuePair[K, V]
+		if err := json.Unmarshal(trimmed, &pairs); err != nil {
+			return err
+		}
+		om.clear()
+		for _, pair := range pairs {
+			om.os.ReplaceOrInsert(pair)
+		}
+		return nil
+	}
+
+	// decode into a slice first so that the map is left unchanged on error
+	var pairs []KeyValuePair[K, V]
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	if token, err := decoder.Token(); err != nil {
+		return err
+	} else if token != json.Delim('{') {
+		return fmt.Errorf("orderedmap: cannot unmarshal %v into OrderedMap", token)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var pair KeyValuePair[K, V]
+		if err := unmarshalTextKey(token.(string), &pair.key); err != nil {
+			return err
+		}
+		if err := decoder.Decode(&pair.value); err != nil {
+			return err
+		}
+		pairs = append(pairs, pair)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	om.clear()
+	for _, pair := range pairs {
+		om.os.ReplaceOrInsert(pair)
+	}
+	return nil
+}
+
+func (om *OrderedMap[K, V]) clear() {
+	for om.Len() > 0 {
+		om.DeleteMin()
+	}
+}
+
+// Returns true if keys of type K can be used as JSON object keys
+func isTextKey[K any]() bool {
+	var key K
+	if _, ok := any(key).(encoding.TextMarshaler); ok {
+		return true
+	}
+	return reflect.TypeOf(&key).Elem().Kind() == reflect.String
+}
+
+func marshalTextKey[K any](key K) (string, error) {
+	if reflect.TypeOf(&key).Elem().Kind() == reflect.String {
+		return reflect.ValueOf(key).String(), nil
+	}
+	text, err := any(key).(encoding.TextMarshaler).MarshalText()
+	return string(text), err
+}
+
+func unmarshalTextKey[K any](text string, key *K) error {
+	if reflect.TypeOf(key).Elem().Kind() == reflect.String {
+		reflect.ValueOf(key).Elem().SetString(text)
+		return nil
+	}
+	if textUnmarshaler, ok := any(key).(encoding.TextUnmarshaler); ok {
+		return textUnmarshaler.UnmarshalText([]byte(text))
+	}
+	var zero K
+	return fmt.Errorf("orderedmap: cannot unmarshal JSON object key into %T", zero)
+}
@@ -0,0 +1,111 @@
+package orderedmap_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/storybehind/gocontainer/orderedmap"
+)
+
+type celsius float64
+
+func TestOrderedMapJSONObject(t *testing.T) {
+	// keys in reverse alphabetical order to check that map order is kept rather than sorted by encoding/json
+	om := orderedmap.New[string, celsius](func(k1, k2 string) bool { return k1 > k2 })
+	om.ReplaceOrInsert("berlin", 12.5)
+	om.ReplaceOrInsert("oslo", 3)
+	om.ReplaceOrInsert("\"quoted\"", 0)
+	data, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v", err)
+	}
+	exp := `{"oslo":3,"berlin":12.5,"\"quoted\"":0}`
+	if string(data) != exp {
+		t.Errorf("Marshal() = %s, exp = %s", data, exp)
+	}
+
+	decoded := orderedmap.NewByTag[string, celsius](func(k1, k2 string) bool { return k1 > k2 }, orderedmap.AvlTreeTag)
+	decoded.ReplaceOrInsert("paris", 20)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal() err = %v", err)
+	}
+	if _, ok := decoded.Get("paris"); ok || decoded.Len() != 3 {
+		t.Errorf("Unmarshal() should replace contents of the map")
+	}
+	if pair, ok := decoded.Get("berlin"); !ok || pair.GetValue() != 12.5 {
+		t.Errorf("Get(berlin) = (%v, %v), exp = (12.5, true)", pair.GetValue(), ok)
+	}
+	if err := json.Unmarshal([]byte(`{"rome": "warm"}`), decoded); err == nil {
+		t.Errorf("Unmarshal() of invalid value; exp error")
+	}
+	if decoded.Len() != 3 {
+		t.Errorf("Len() after failed Unmarshal = %d, exp = 3", decoded.Len())
+	}
+}
+
+func TestOrderedMapJSONTextMarshaler(t *testing.T) {
+	less := func(t1, t2 time.Time) bool { return t1.Before(t2) }
+	om := orderedmap.New[time.Time, int](less)
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	om.ReplaceOrInsert(day.AddDate(0, 0, 1), 2)
+	om.ReplaceOrInsert(day, 1)
+	data, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v", err)
+	}
+	exp := `{"2020-01-01T00:00:00Z":1,"2020-01-02T00:00:00Z":2}`
+	if string(data) != exp {
+		t.Errorf("Marshal() = %s, exp = %s", data, exp)
+	}
+	decoded := orderedmap.New[time.Time, int](less)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal() err = %v", err)
+	}
+	if pair, ok := decoded.Min(); !ok || !pair.GetKey().Equal(day) || pair.GetValue() != 1 {
+		t.Errorf("Min() = (%v, %v), exp = (%v, 1)", pair.GetKey(), pair.GetValue(), day)
+	}
+}
+
+func TestOrderedMapJSONArray(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	om := orderedmap.New[int, string](less)
+	om.ReplaceOrInsert(10, "ten")
+	om.ReplaceOrInsert(2, "two")
+	data, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v", err)
+	}
+	exp := `[{"key":2,"value":"two"},{"key":10,"value":"ten"}]`
+	if string(data) != exp {
+		t.Errorf("Marshal() = %s, exp = %s", data, exp)
+	}
+
+	decoded := orderedmap.New[int, string](less)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal() err = %v", err)
+	}
+	if pair, ok := decoded.Max(); !ok || pair.GetKey() != 10 || pair.GetValue() != "ten" {
+		t.Errorf("Max() = (%d, %s), exp = (10, ten)", pair.GetKey(), pair.GetValue())
+	}
+	if err := json.Unmarshal([]byte(`{"1": "one"}`), decoded); err == nil || !strings.Contains(err.Error(), "cannot unmarshal") {
+		t.Errorf("Unmarshal() of JSON object into map with int keys err = %v; exp cannot unmarshal error", err)
+	}
+
+	var uninitialized orderedmap.OrderedMap[int, string]
+	if err := json.Unmarshal(data, &uninitialized); err == nil {
+		t.Errorf("Unmarshal() into map not created by constructor; exp error")
+	}
+}
+
+func TestKeyValuePairJSON(t *testing.T) {
+	data, err := json.Marshal(orderedmap.NewKeyValuePair[string, int]("a", 1))
+	if err != nil || string(data) != `{"key":"a","value":1}` {
+		t.Errorf("Marshal() = (%s, %v), exp = ({\"key\":\"a\",\"value\":1}, nil)", data, err)
+	}
+	var pair orderedmap.KeyValuePair[string, int]
+	if err := json.Unmarshal(data, &pair); err != nil || pair.GetKey() != "a" || pair.GetValue() != 1 {
+		t.Errorf("Unmarshal() = (%v, %v), exp = ({a 1}, nil)", pair, err)
+	}
+}
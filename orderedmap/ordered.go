@@ -0,0 +1,15 @@
+package orderedmap
+
+import "cmp"
+
+// Returns instance of OrderedMap ordering keys ascending by cmp.Compare.
+// By  default, underlying set structure is RbTree.
+func NewOrdered[K cmp.Ordered, V any]() *OrderedMap[K, V] {
+	return NewByTagFunc[K, V](cmp.Compare[K], RbTreeTag)
+}
+
+// Returns instance of OrderedMap ordering keys by cmp.Compare.
+// tag specifies underlying set structure. Can be AvlTreeTag or RbTreeTag.
+func NewOrderedByTag[K cmp.Ordered, V any](tag Tag) *OrderedMap[K, V] {
+	return NewByTagFunc[K, V](cmp.Compare[K], tag)
+}
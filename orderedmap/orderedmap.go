@@ -1,11 +1,17 @@
 package orderedmap
 
-import "github.com/storybehind/gocontainer/orderedset"
+import (
+	"github.com/storybehind/gocontainer/codec"
+	"github.com/storybehind/gocontainer/orderedset"
+)
 
 // Container to maintain key value pairs where all keys are unique
 // Supports insertion, deletion and search operation in O(log n) time where n is number of keys in the map
 type OrderedMap[K, V any] struct {
 	os orderedset.OrderedSetI[KeyValuePair[K, V]]
+	// codecs used by binary encoding. nil means codec.Default
+	keyCodec   codec.Codec[K]
+	valueCodec codec.Codec[V]
 }
 
 // Stores key and value. Use GetKey() and GetValue() to retrieve key and value respectively
@@ -73,6 +79,32 @@ func NewByTag[K, V any](less func(k1, k2 K) bool, tag Tag) *OrderedMap[K, V] {
 	}
 }
 
+// Returns instance of OrderedMap ordered by three-way comparison function of keys as in cmp.Compare.
+// By  default, underlying set structure is RbTree.
+func NewFunc[K, V any](cmp func(k1, k2 K) int) *OrderedMap[K, V] {
+	return NewByTagFunc[K, V](cmp, RbTreeTag)
+}
+
+// Returns instance of OrderedMap ordered by three-way comparison function of keys as in NewFunc.
+// tag specifies underlying set structure. Can be AvlTreeTag or RbTreeTag.
+func NewByTagFunc[K, V any](cmp func(k1, k2 K) int, tag Tag) *OrderedMap[K, V] {
+	pairCmp := func(k1, k2 KeyValuePair[K, V]) int {
+		return cmp(k1.key, k2.key)
+	}
+	switch tag {
+	case AvlTreeTag:
+		return &OrderedMap[K, V]{
+			os: orderedset.NewAvlTreeFunc[KeyValuePair[K, V]](pairCmp),
+		}
+	case RbTreeTag:
+		return &OrderedMap[K, V]{
+			os: orderedset.NewRbTreeFunc[KeyValuePair[K, V]](pairCmp),
+		}
+	default:
+		panic("invalid tag type")
+	}
+}
+
 // Get looks for the key in the tree, returning its KeyValuePair. It returns (zeroValue, false) if unable to find that key
 func (om *OrderedMap[K, V]) Get(key K) (_ KeyValuePair[K, V], _ bool) {
 	return om.os.Get(KeyValuePair[K, V]{
@@ -183,3 +183,19 @@ func TestOrderedMapAvlTreeTag(t *testing.T) {
 	}, orderedmap.AvlTreeTag)
 	testOrderedMap(t, om)
 }
+
+func TestOrderedMapOrdered(t *testing.T) {
+	testOrderedMap(t, orderedmap.NewOrdered[int, int]())
+	testOrderedMap(t, orderedmap.NewOrderedByTag[int, int](orderedmap.AvlTreeTag))
+	// comparators may return any negative or positive number, not only -1 and 1
+	cmp := func(k1, k2 int) int { return (k1 - k2) * 1000 }
+	testOrderedMap(t, orderedmap.NewFunc[int, int](cmp))
+	testOrderedMap(t, orderedmap.NewByTagFunc[int, int](cmp, orderedmap.AvlTreeTag))
+
+	om := orderedmap.NewOrdered[string, int]()
+	om.ReplaceOrInsert("b", 2)
+	om.ReplaceOrInsert("a", 1)
+	if s := om.String(); s != "map[a:1 b:2]" {
+		t.Errorf("om = %s, exp = map[a:1 b:2]", s)
+	}
+}
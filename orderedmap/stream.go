@@ -0,0 +1,166 @@
+package orderedmap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+
+	"github.com/storybehind/gocontainer/codec"
+)
+
+// ErrChecksumMismatch is returned by Decode when a chunk or the trailer does not match its checksum
+var ErrChecksumMismatch = errors.New("orderedmap: checksum mismatch")
+
+// target size of a chunk payload in bytes
+const streamChunkSize = 64 << 10
+
+// Writes key value pairs of m to w in ascending order of keys without materializing the whole encoding in memory.
+// Stream consists of a header with number of pairs, chunks of pairs each followed by crc32 checksum of its payload,
+// and a trailer with number of pairs and checksum of all payloads.
+// Pairs are encoded with c, which can be built by KeyValuePairCodec.
+func Encode[K, V any](w io.Writer, m *OrderedMap[K, V], c codec.Codec[KeyValuePair[K, V]]) error {
+	if _, err := w.Write(codec.AppendHeader(nil, codec.OrderedMapStreamKind, m.Len())); err != nil {
+		return err
+	}
+	total := crc32.NewIEEE()
+	var payload, chunk []byte
+	count := 0
+	flush := func() error {
+		chunk = binary.AppendUvarint(chunk[:0], uint64(count))
+		chunk = codec.AppendBytes(chunk, payload)
+		chunk = binary.LittleEndian.AppendUint32(chunk, crc32.ChecksumIEEE(payload))
+		total.Write(payload)
+		payload = payload[:0]
+		count = 0
+		_, err := w.Write(chunk)
+		return err
+	}
+
+	iterator := m.Begin()
+	for pair, ok := iterator.Key(); ok; pair, ok = iterator.Next() {
+		var err error
+		if payload, err = c.Append(payload, pair); err != nil {
+			return err
+		}
+		count++
+		if len(payload) >= streamChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if count > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	// trailer starts with an empty chunk marker
+	trailer := binary.AppendUvarint(nil, 0)
+	trailer = binary.LittleEndian.AppendUint64(trailer, uint64(m.Len()))
+	trailer = binary.LittleEndian.AppendUint32(trailer, total.Sum32())
+	_, err := w.Write(trailer)
+	return err
+}
+
+// Reads stream written by Encode from r and returns OrderedMap built incrementally as chunks arrive, without holding the whole encoding in memory.
+// Pairs are decoded with c. less must order keys same as the map that was encoded.
+// Returns ErrChecksumMismatch if stream is corrupted and io.ErrUnexpectedEOF if it is truncated.
+func Decode[K, V any](r io.Reader, less func(k1, k2 K) bool, c codec.Codec[KeyValuePair[K, V]]) (*OrderedMap[K, V], error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		buffered := bufio.NewReader(r)
+		r, br = buffered, buffered
+	}
+	length, err := codec.ReadHeaderFrom(br, codec.OrderedMapStreamKind)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	m := New[K, V](less)
+	total := crc32.NewIEEE()
+	var payload []byte
+	var prev KeyValuePair[K, V]
+	for {
+		count, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if count == 0 {
+			break
+		}
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if size <= streamChunkSize*2 {
+			if uint64(cap(payload)) < size {
+				payload = make([]byte, size)
+			}
+			payload = payload[:size]
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+		} else {
+			// oversized chunk holding a large pair. Read it growing buffer gradually so that a corrupt size does not allocate huge buffer upfront
+			if size > math.MaxInt64 {
+				return nil, codec.ErrCorrupt
+			}
+			if payload, err = io.ReadAll(io.LimitReader(r, int64(size))); err != nil {
+				return nil, err
+			}
+			if uint64(len(payload)) != size {
+				return nil, io.ErrUnexpectedEOF
+			}
+		}
+		var checksum [4]byte
+		if _, err := io.ReadFull(r, checksum[:]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(checksum[:]) {
+			return nil, ErrChecksumMismatch
+		}
+		total.Write(payload)
+
+		buf := payload
+		for i := uint64(0); i < count; i++ {
+			pair, n, err := c.Decode(buf)
+			if err != nil {
+				return nil, err
+			}
+			buf = buf[n:]
+			if m.Len() > 0 && !less(prev.key, pair.key) {
+				return nil, errors.New("orderedmap: keys are not in strictly ascending order")
+			}
+			m.ReplaceOrInsert(pair.key, pair.value)
+			prev = pair
+		}
+		if len(buf) > 0 {
+			return nil, codec.ErrCorrupt
+		}
+	}
+
+	var trailer [12]byte
+	if _, err := io.ReadFull(r, trailer[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if binary.LittleEndian.Uint32(trailer[8:]) != total.Sum32() {
+		return nil, ErrChecksumMismatch
+	}
+	if count := binary.LittleEndian.Uint64(trailer[:8]); count != uint64(length) || length != m.Len() {
+		return nil, fmt.Errorf("orderedmap: stream has %d pairs, expected %d", m.Len(), length)
+	}
+	return m, nil
+}
+
+// stream ending before trailer is always unexpected
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package orderedmap_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/storybehind/gocontainer/codec"
+	"github.com/storybehind/gocontainer/orderedmap"
+)
+
+func encodeStream(t *testing.T, om *orderedmap.OrderedMap[int, string]) []byte {
+	var buf bytes.Buffer
+	c := orderedmap.KeyValuePairCodec[int, string](codec.Default[int](), codec.Default[string]())
+	if err := orderedmap.Encode[int, string](&buf, om, c); err != nil {
+		t.Fatalf("Encode() err = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decodeStream(r io.Reader, less func(k1, k2 int) bool) (*orderedmap.OrderedMap[int, string], error) {
+	c := orderedmap.KeyValuePairCodec[int, string](codec.Default[int](), codec.Default[string]())
+	return orderedmap.Decode[int, string](r, less, c)
+}
+
+func TestStream(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	for _, n := range []int{0, 1, 50000} {
+		om := orderedmap.New[int, string](less)
+		for key := 0; key < n; key++ {
+			om.ReplaceOrInsert(key*7, strings.Repeat("x", key%5))
+		}
+		data := encodeStream(t, om)
+		// reader without ReadByte
+		decoded, err := decodeStream(io.MultiReader(bytes.NewReader(data)), less)
+		if err != nil {
+			t.Fatalf("Decode() of %d pairs err = %v", n, err)
+		}
+		if decoded.Len() != int64(n) {
+			t.Fatalf("Len() = %d, exp = %d", decoded.Len(), n)
+		}
+		iterator := decoded.Begin()
+		for key := 0; key < n; key++ {
+			pair, ok := iterator.Key()
+			if !ok || pair.GetKey() != key*7 || pair.GetValue() != strings.Repeat("x", key%5) {
+				t.Fatalf("found pair = (%d, %s), exp = (%d, %s)", pair.GetKey(), pair.GetValue(), key*7, strings.Repeat("x", key%5))
+			}
+			iterator.Next()
+		}
+	}
+}
+
+func TestStreamLargeValue(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	om := orderedmap.New[int, string](less)
+	om.ReplaceOrInsert(1, strings.Repeat("y", 1<<20))
+	om.ReplaceOrInsert(2, "z")
+	decoded, err := decodeStream(bytes.NewReader(encodeStream(t, om)), less)
+	if err != nil {
+		t.Fatalf("Decode() err = %v", err)
+	}
+	if pair, _ := decoded.Min(); len(pair.GetValue()) != 1<<20 {
+		t.Errorf("len of decoded value = %d, exp = %d", len(pair.GetValue()), 1<<20)
+	}
+}
+
+func TestStreamCorrupt(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	om := orderedmap.New[int, string](less)
+	for key := 0; key < 1000; key++ {
+		om.ReplaceOrInsert(key, "value")
+	}
+	data := encodeStream(t, om)
+
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	if _, err := decodeStream(bytes.NewReader(corrupted), less); !errors.Is(err, orderedmap.ErrChecksumMismatch) {
+		t.Errorf("Decode() of corrupted stream err = %v, exp = %v", err, orderedmap.ErrChecksumMismatch)
+	}
+	for _, size := range []int{0, 3, len(data) / 2, len(data) - 1} {
+		if _, err := decodeStream(bytes.NewReader(data[:size]), less); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Decode() of stream truncated to %d bytes err = %v, exp = %v", size, err, io.ErrUnexpectedEOF)
+		}
+	}
+	if _, err := decodeStream(bytes.NewReader(data), func(k1, k2 int) bool { return k1 > k2 }); err == nil {
+		t.Errorf("Decode() with different order; exp error")
+	}
+}
@@ -0,0 +1,11 @@
+package orderedmap
+
+// Validate checks invariants of the underlying set structure as RbTree.Validate or AvlTree.Validate do.
+// It returns nil if map is valid. Otherwise, an error wrapping orderedset.ErrInvalidTree describes the first violation found.
+// Takes O(n) time where n is number of keys in the map.
+func (om *OrderedMap[K, V]) Validate() error {
+	if validator, ok := om.os.(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+	return nil
+}
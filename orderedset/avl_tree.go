@@ -1,5 +1,7 @@
 package orderedset
 
+import "github.com/storybehind/gocontainer/codec"
+
 //Node of avl tree that holds a particular key
 //Maintain left, right and parent pointer for tree traversal
 type avlTreeNode[K any] struct {
@@ -28,6 +30,11 @@ func (node *avlTreeNode[K]) GetKey() K {
 	return node.key
 }
 
+// Returns height of subtree rooted at node. Leaf node has height 1
+func (node *avlTreeNode[K]) GetHeight() int64 {
+	return node.height
+}
+
 // Maintains unique set of keys.
 // Supports insertion, deletion and search operation in O(log n) time where n is number of keys in the set
 type AvlTree[K any] struct {
@@ -36,6 +43,8 @@ type AvlTree[K any] struct {
 	less     func(k1, k2 K) bool
 	cmp      compare[K]
 	len      int64
+	// codec of keys used by binary encoding. nil means codec.Default
+	codec    codec.Codec[K]
 }
 
 // Returns instance of AvlTree.
@@ -43,6 +52,15 @@ type AvlTree[K any] struct {
 // k1 precedes k2 in AvlTree if and only if Less(k1, k2) return true.
 // k1 equals k2 if and only if !Less(k1, k2) && !Less(k2, k1) holds true.
 func NewAvlTree[K any](less func(k1, k2 K) bool) *AvlTree[K] {
+	return newAvlTree[K](less, lessCompare[K](less))
+}
+
+// Returns instance of AVL Tree ordered by three-way comparison function as in cmp.Compare.
+func NewAvlTreeFunc[K any](cmp func(k1, k2 K) int) *AvlTree[K] {
+	return newAvlTree[K](compareLess[K](cmp), cmp)
+}
+
+func newAvlTree[K any](less func(k1, k2 K) bool, cmp compare[K]) *AvlTree[K] {
 	sentinel := &avlTreeNode[K]{
 		height: 0,
 	}
@@ -50,15 +68,7 @@ func NewAvlTree[K any](less func(k1, k2 K) bool) *AvlTree[K] {
 		root:     sentinel,
 		less:     less,
 		sentinel: sentinel,
-		cmp: func(k1, k2 K) int {
-			if less(k1, k2) {
-				return -1
-			}
-			if less(k2, k1) {
-				return 1
-			}
-			return 0
-		},
+		cmp:      cmp,
 		len: 0,
 	}
 }
@@ -131,6 +141,16 @@ func (avlTree *AvlTree[K]) Len() int64 {
 	return avlTree.len
 }
 
+// Returns root node of the tree.
+func (avlTree *AvlTree[K]) GetRoot() BBSTNode[K] {
+	return avlTree.root
+}
+
+// Returns sentinel node of the tree (can be thought of nil leaf nodes or root's parent)
+func (avlTree *AvlTree[K]) GetSentinel() BBSTNode[K] {
+	return avlTree.sentinel
+}
+
 // toRemove details what item to remove in a node.remove call.
 type toRemove int
 
@@ -206,16 +226,16 @@ func (avlTree *AvlTree[K]) replaceOrInsert(node *avlTreeNode[K], key K) (_ *avlT
 	compare := avlTree.cmp(key, node.key)
 	var prevKey K
 	var has bool
-	switch compare {
-	case 0:
+	switch {
+	case compare == 0:
 		prevKey = node.key
 		node.key = key
 		has = true
 		return node, prevKey, has
-	case -1:
+	case compare < 0:
 		node.left, prevKey, has = avlTree.replaceOrInsert(node.left, key)
 		node.left.parent = node
-	case 1:
+	default:
 		node.right, prevKey, has = avlTree.replaceOrInsert(node.right, key)
 		node.right.parent = node
 	}
@@ -268,8 +288,8 @@ func (avlTree AvlTree[K]) delete(node *avlTreeNode[K], key K, typ toRemove) (_ *
 		node.right.parent = node
 	case removeKey:
 		compare := avlTree.cmp(key, node.key)
-		switch compare {
-		case 0:
+		switch {
+		case compare == 0:
 			deletedKey = node.key
 			deleted = true
 			if node.left != avlTree.sentinel {
@@ -287,10 +307,12 @@ func (avlTree AvlTree[K]) delete(node *avlTreeNode[K], key K, typ toRemove) (_ *
 			} else {
 				return avlTree.sentinel, node.key, true
 			}
-		case -1:
+			node.left.parent = node
+			node.right.parent = node
+		case compare < 0:
 			node.left, deletedKey, deleted = avlTree.delete(node.left, key, typ)
 			node.left.parent = node
-		case 1:
+		default:
 			node.right, deletedKey, deleted = avlTree.delete(node.right, key, typ)
 			node.right.parent = node
 		}
@@ -385,6 +407,9 @@ func (avlIterator *AvlIterator[K]) Key() (_ K, _ bool) {
 func (avlIterator *AvlIterator[K]) Remove() (_ K, _ bool) {
 	var todelete *avlTreeNode[K] = avlIterator.next
 	var avlTree *AvlTree[K] = avlIterator.avlTree
+	if todelete == avlTree.sentinel {
+		panic("iterator has completed traversing all the keys")
+	}
 	nextKey, hasNext := avlIterator.Next()
 	avlTree.Delete(todelete.key)
 	return nextKey, hasNext
@@ -434,6 +459,9 @@ func (reverseAvlIterator *ReverseAvlIterator[K]) Key() (_ K, _ bool) {
 // panics on calling Remove() in empty tree or an iterator has completed traversing all the keys
 func (reverseAvlIterator *ReverseAvlIterator[K]) Remove() (_ K, _ bool) {
 	var todelete *avlTreeNode[K] = reverseAvlIterator.prev
+	if todelete == reverseAvlIterator.avlTree.sentinel {
+		panic("iterator has completed traversing all the keys")
+	}
 	key, hasPrev := reverseAvlIterator.Prev()
 	reverseAvlIterator.avlTree.Delete(todelete.key)
 	return key, hasPrev
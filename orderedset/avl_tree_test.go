@@ -20,3 +20,77 @@ func TestAvlTreeReverseIterator(t *testing.T) {
 	avlTree := orderedset.NewAvlTree[int](func(k1, k2 int) bool { return k1 < k2 })
 	testOrderedSetReverseIterator(t, avlTree)
 }
+
+func TestAvlTreeDeleteInternalNode(t *testing.T) {
+	avlTree := orderedset.NewAvlTree[int](func(k1, k2 int) bool { return k1 < k2 })
+	const n = 128
+	for key := 0; key < n; key++ {
+		avlTree.ReplaceOrInsert(key)
+	}
+	present := make([]bool, n)
+	for key := range present {
+		present[key] = true
+	}
+	// deleting keys from the middle outwards removes nodes having two children
+	for i := 0; i < n; i++ {
+		key := (n/2 + (i+1)/2*(1-2*(i%2)) + n) % n
+		if _, ok := avlTree.Delete(key); !ok {
+			t.Fatalf("Delete(%d) = false; exp = true", key)
+		}
+		present[key] = false
+
+		var forward, reverse []int
+		itr := avlTree.Begin()
+		for key, ok := itr.Key(); ok && len(forward) <= n; key, ok = itr.Next() {
+			forward = append(forward, key)
+		}
+		reverseItr := avlTree.Rbegin()
+		for key, ok := reverseItr.Key(); ok && len(reverse) <= n; key, ok = reverseItr.Prev() {
+			reverse = append(reverse, key)
+		}
+		var exp []int
+		for key := range present {
+			if present[key] {
+				exp = append(exp, key)
+			}
+		}
+		if len(forward) != len(exp) || len(reverse) != len(exp) {
+			t.Fatalf("after deleting %d, iterators returned %v and %v; exp = %v", key, forward, reverse, exp)
+		}
+		for j := range exp {
+			if forward[j] != exp[j] || reverse[len(exp)-1-j] != exp[j] {
+				t.Fatalf("after deleting %d, iterators returned %v and %v; exp = %v", key, forward, reverse, exp)
+			}
+		}
+	}
+}
+
+func TestAvlTreeIteratorRemoveAfterEnd(t *testing.T) {
+	avlTree := orderedset.NewAvlTree[int](func(k1, k2 int) bool { return k1 < k2 })
+	for _, key := range []int{0, 1, 2} {
+		avlTree.ReplaceOrInsert(key)
+	}
+	expectPanic := func(name string, remove func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s on exhausted iterator did not panic", name)
+			}
+		}()
+		remove()
+	}
+
+	itr := avlTree.Begin()
+	for _, ok := itr.Key(); ok; _, ok = itr.Next() {
+	}
+	expectPanic("AvlIterator.Remove", func() { itr.Remove() })
+
+	reverseItr := avlTree.Rbegin()
+	for _, ok := reverseItr.Key(); ok; _, ok = reverseItr.Prev() {
+	}
+	expectPanic("ReverseAvlIterator.Remove", func() { reverseItr.Remove() })
+
+	if _, ok := avlTree.Get(0); !ok || avlTree.Len() != 3 {
+		t.Errorf("Get(0) = %v, Len() = %d; exp = true, 3", ok, avlTree.Len())
+	}
+}
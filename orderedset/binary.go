@@ -0,0 +1,124 @@
+package orderedset
+
+import (
+	"errors"
+
+	"github.com/storybehind/gocontainer/codec"
+)
+
+var errUnmarshalBinaryUninitialized = errors.New("orderedset: UnmarshalBinary on tree not created by constructor")
+
+// Sets codec of keys used by MarshalBinary and UnmarshalBinary. By default, codec.Default[K]() is used.
+func (rbTree *RbTree[K]) SetCodec(c codec.Codec[K]) {
+	rbTree.codec = c
+}
+
+// Encodes the tree as versioned header followed by keys in ascending order. Implements encoding.BinaryMarshaler.
+func (rbTree *RbTree[K]) MarshalBinary() ([]byte, error) {
+	return codec.Marshal[K](codec.OrderedSetKind, collectKeys[K](rbTree.Begin(), rbTree.len), keyCodec(rbTree.codec))
+}
+
+// Decodes data written by MarshalBinary of any ordered set, replacing contents of the tree in O(n) time after decoding.
+// Implements encoding.BinaryUnmarshaler. Tree must be created by NewRbTree since less function cannot be decoded.
+func (rbTree *RbTree[K]) UnmarshalBinary(data []byte) error {
+	if rbTree.less == nil {
+		return errUnmarshalBinaryUninitialized
+	}
+	keys, err := codec.Unmarshal[K](data, codec.OrderedSetKind, keyCodec(rbTree.codec))
+	if err != nil {
+		return err
+	}
+	return rbTree.ReplaceAllSorted(keys)
+}
+
+// Same as MarshalBinary. Implements gob.GobEncoder.
+func (rbTree *RbTree[K]) GobEncode() ([]byte, error) {
+	return rbTree.MarshalBinary()
+}
+
+// Same as UnmarshalBinary. Implements gob.GobDecoder.
+func (rbTree *RbTree[K]) GobDecode(data []byte) error {
+	return rbTree.UnmarshalBinary(data)
+}
+
+// Sets codec of keys used by MarshalBinary and UnmarshalBinary. By default, codec.Default[K]() is used.
+func (avlTree *AvlTree[K]) SetCodec(c codec.Codec[K]) {
+	avlTree.codec = c
+}
+
+// Encodes the tree as versioned header followed by keys in ascending order. Implements encoding.BinaryMarshaler.
+func (avlTree *AvlTree[K]) MarshalBinary() ([]byte, error) {
+	return codec.Marshal[K](codec.OrderedSetKind, collectKeys[K](avlTree.Begin(), avlTree.len), keyCodec(avlTree.codec))
+}
+
+// Decodes data written by MarshalBinary of any ordered set, replacing contents of the tree in O(n) time after decoding.
+// Implements encoding.BinaryUnmarshaler. Tree must be created by NewAvlTree since less function cannot be decoded.
+func (avlTree *AvlTree[K]) UnmarshalBinary(data []byte) error {
+	if avlTree.less == nil {
+		return errUnmarshalBinaryUninitialized
+	}
+	keys, err := codec.Unmarshal[K](data, codec.OrderedSetKind, keyCodec(avlTree.codec))
+	if err != nil {
+		return err
+	}
+	return avlTree.ReplaceAllSorted(keys)
+}
+
+// Same as MarshalBinary. Implements gob.GobEncoder.
+func (avlTree *AvlTree[K]) GobEncode() ([]byte, error) {
+	return avlTree.MarshalBinary()
+}
+
+// Same as UnmarshalBinary. Implements gob.GobDecoder.
+func (avlTree *AvlTree[K]) GobDecode(data []byte) error {
+	return avlTree.UnmarshalBinary(data)
+}
+
+// Sets codec of keys used by MarshalBinary and UnmarshalBinary. By default, codec.Default[K]() is used.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) SetCodec(c codec.Codec[K]) {
+	rbTreeAugmented.codec = c
+}
+
+// Encodes the tree as versioned header followed by keys in ascending order. Augmented values are not encoded.
+// Implements encoding.BinaryMarshaler.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) MarshalBinary() ([]byte, error) {
+	return codec.Marshal[K](codec.OrderedSetKind, collectKeys[K](rbTreeAugmented.Begin(), rbTreeAugmented.len), keyCodec(rbTreeAugmented.codec))
+}
+
+// Decodes data written by MarshalBinary of any ordered set, replacing contents of the tree and recomputing augmented values in O(t * n) time after decoding.
+// Implements encoding.BinaryUnmarshaler. Tree must be created by NewRbTreeAugmented since less and update functions cannot be decoded.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) UnmarshalBinary(data []byte) error {
+	if rbTreeAugmented.less == nil {
+		return errUnmarshalBinaryUninitialized
+	}
+	keys, err := codec.Unmarshal[K](data, codec.OrderedSetKind, keyCodec(rbTreeAugmented.codec))
+	if err != nil {
+		return err
+	}
+	return rbTreeAugmented.ReplaceAllSorted(keys)
+}
+
+// Same as MarshalBinary. Implements gob.GobEncoder.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) GobEncode() ([]byte, error) {
+	return rbTreeAugmented.MarshalBinary()
+}
+
+// Same as UnmarshalBinary. Implements gob.GobDecoder.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) GobDecode(data []byte) error {
+	return rbTreeAugmented.UnmarshalBinary(data)
+}
+
+func keyCodec[K any](c codec.Codec[K]) codec.Codec[K] {
+	if c == nil {
+		return codec.Default[K]()
+	}
+	return c
+}
+
+func collectKeys[K any](iterator OrderedSetForwardIterator[K], len int64) []K {
+	keys := make([]K, 0, len)
+	for key, ok := iterator.Key(); ok; key, ok = iterator.Next() {
+		keys = append(keys, key)
+	}
+	return keys
+}
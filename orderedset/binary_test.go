@@ -0,0 +1,112 @@
+package orderedset_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math/rand"
+	"sort"
+	"testing"
+
+	orderedset "github.com/storybehind/gocontainer/orderedset"
+)
+
+type binaryOrderedSet interface {
+	orderedset.OrderedSetI[int]
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	ReplaceAllSorted(keys []int) error
+}
+
+// checks that os contains exactly keys and keeps doing so under random updates
+func checkOrderedSetKeys(t *testing.T, os orderedset.OrderedSetI[int], keys []int, r *rand.Rand) {
+	expected := make(map[int]bool)
+	for _, key := range keys {
+		expected[key] = true
+	}
+	for i := 0; i < 200; i++ {
+		key := r.Intn(300)
+		if r.Intn(2) == 0 {
+			os.ReplaceOrInsert(key)
+			expected[key] = true
+		} else {
+			os.Delete(key)
+			delete(expected, key)
+		}
+	}
+	expKeys := make([]int, 0, len(expected))
+	for key := range expected {
+		expKeys = append(expKeys, key)
+	}
+	sort.Ints(expKeys)
+	if os.Len() != int64(len(expKeys)) {
+		t.Fatalf("Len() = %d, exp = %d", os.Len(), len(expKeys))
+	}
+	iterator := os.Begin()
+	for _, expKey := range expKeys {
+		if key, ok := iterator.Key(); !ok || key != expKey {
+			t.Fatalf("found key = %d, exp = %d", key, expKey)
+		}
+		iterator.Next()
+	}
+}
+
+func testOrderedSetBinary(t *testing.T, newOrderedSet func() binaryOrderedSet) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n <= 64; n++ {
+		keys := make([]int, n)
+		for i := range keys {
+			keys[i] = 3 * i
+		}
+		os := newOrderedSet()
+		if err := os.ReplaceAllSorted(keys); err != nil {
+			t.Fatalf("ReplaceAllSorted() err = %v", err)
+		}
+		data, err := os.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() err = %v", err)
+		}
+		decoded := newOrderedSet()
+		decoded.ReplaceOrInsert(-1)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary() err = %v", err)
+		}
+		checkOrderedSetKeys(t, decoded, keys, r)
+	}
+
+	os := newOrderedSet()
+	os.ReplaceOrInsert(1)
+	if err := os.ReplaceAllSorted([]int{1, 3, 3}); err == nil {
+		t.Errorf("ReplaceAllSorted() of keys with duplicates; exp error")
+	}
+	if err := os.UnmarshalBinary([]byte("junk")); err == nil {
+		t.Errorf("UnmarshalBinary() of junk; exp error")
+	}
+	if key, _ := os.Min(); os.Len() != 1 || key != 1 {
+		t.Errorf("failed ReplaceAllSorted or UnmarshalBinary should leave the tree unchanged")
+	}
+}
+
+func TestOrderedSetBinary(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	testOrderedSetBinary(t, func() binaryOrderedSet { return orderedset.NewRbTree[int](less) })
+	testOrderedSetBinary(t, func() binaryOrderedSet { return orderedset.NewAvlTree[int](less) })
+}
+
+func TestOrderedSetGob(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	rbTree := orderedset.NewRbTree[int](less)
+	for _, key := range []int{5, 1, 9} {
+		rbTree.ReplaceOrInsert(key)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rbTree); err != nil {
+		t.Fatalf("Encode() err = %v", err)
+	}
+	// encoding of RbTree can be decoded into AvlTree
+	avlTree := orderedset.NewAvlTree[int](less)
+	if err := gob.NewDecoder(&buf).Decode(avlTree); err != nil {
+		t.Fatalf("Decode() err = %v", err)
+	}
+	checkOrderedSetKeys(t, avlTree, []int{1, 5, 9}, rand.New(rand.NewSource(1)))
+}
@@ -0,0 +1,106 @@
+package orderedset
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var errNotSorted = errors.New("orderedset: keys are not in strictly ascending order")
+
+// Replaces contents of the tree with keys, which must be in strictly ascending order. Returns error and leaves the tree unchanged otherwise.
+// Builds a balanced tree in O(n) time where n = len(keys).
+func (rbTree *RbTree[K]) ReplaceAllSorted(keys []K) error {
+	if !isStrictlySorted(keys, rbTree.less) {
+		return errNotSorted
+	}
+	redDepth := bits.Len64(uint64(len(keys))+1) - 1
+	var build func(lo, hi, depth int, parent *rbTreeNode[K]) *rbTreeNode[K]
+	build = func(lo, hi, depth int, parent *rbTreeNode[K]) *rbTreeNode[K] {
+		if lo > hi {
+			return rbTree.sentinel
+		}
+		mid := lo + (hi-lo)/2
+		node := &rbTreeNode[K]{
+			parent: parent,
+			key:    keys[mid],
+			color:  BLACK,
+		}
+		// every path has same black nodes as nodes of partially filled deepest level are colored red
+		if depth == redDepth {
+			node.color = RED
+		}
+		node.left = build(lo, mid-1, depth+1, node)
+		node.right = build(mid+1, hi, depth+1, node)
+		return node
+	}
+	rbTree.root = build(0, len(keys)-1, 0, rbTree.sentinel)
+	rbTree.len = int64(len(keys))
+	return nil
+}
+
+// Replaces contents of the tree with keys, which must be in strictly ascending order. Returns error and leaves the tree unchanged otherwise.
+// Builds a balanced tree in O(n) time where n = len(keys).
+func (avlTree *AvlTree[K]) ReplaceAllSorted(keys []K) error {
+	if !isStrictlySorted(keys, avlTree.less) {
+		return errNotSorted
+	}
+	var build func(lo, hi int, parent *avlTreeNode[K]) *avlTreeNode[K]
+	build = func(lo, hi int, parent *avlTreeNode[K]) *avlTreeNode[K] {
+		if lo > hi {
+			return avlTree.sentinel
+		}
+		mid := lo + (hi-lo)/2
+		node := &avlTreeNode[K]{
+			parent: parent,
+			key:    keys[mid],
+		}
+		node.left = build(lo, mid-1, node)
+		node.right = build(mid+1, hi, node)
+		node.height = 1 + max(node.left.height, node.right.height)
+		return node
+	}
+	avlTree.root = build(0, len(keys)-1, avlTree.sentinel)
+	avlTree.len = int64(len(keys))
+	return nil
+}
+
+// Replaces contents of the tree with keys, which must be in strictly ascending order. Returns error and leaves the tree unchanged otherwise.
+// Builds a balanced tree in O(t * n) time where n = len(keys) and t is time required to maintain node's invariant.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) ReplaceAllSorted(keys []K) error {
+	if !isStrictlySorted(keys, rbTreeAugmented.less) {
+		return errNotSorted
+	}
+	sentinel := rbTreeAugmented.sentinel
+	redDepth := bits.Len64(uint64(len(keys))+1) - 1
+	var build func(lo, hi, depth int, parent *rbTreeNodeAugmented[K, A]) *rbTreeNodeAugmented[K, A]
+	build = func(lo, hi, depth int, parent *rbTreeNodeAugmented[K, A]) *rbTreeNodeAugmented[K, A] {
+		if lo > hi {
+			return sentinel
+		}
+		mid := lo + (hi-lo)/2
+		node := &rbTreeNodeAugmented[K, A]{
+			parent: parent,
+			key:    keys[mid],
+			color:  BLACK,
+		}
+		if depth == redDepth {
+			node.color = RED
+		}
+		node.left = build(lo, mid-1, depth+1, node)
+		node.right = build(mid+1, hi, depth+1, node)
+		node.augmentedValue = rbTreeAugmented.updateAugmentValue(node, sentinel)
+		return node
+	}
+	rbTreeAugmented.root = build(0, len(keys)-1, 0, sentinel)
+	rbTreeAugmented.len = int64(len(keys))
+	return nil
+}
+
+func isStrictlySorted[K any](keys []K, less func(k1, k2 K) bool) bool {
+	for i := 1; i < len(keys); i++ {
+		if !less(keys[i-1], keys[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package orderedset
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/storybehind/gocontainer/internal/fmtutil"
+)
+
+func (c color) String() string {
+	if c == RED {
+		return "red"
+	}
+	return "black"
+}
+
+// Returns keys in ascending order like [k1 k2 k3], truncated after fmtutil.DefaultLimit keys
+func (rbTree *RbTree[K]) String() string {
+	return fmt.Sprint(rbTree)
+}
+
+// Implements fmt.Formatter. %v and %s print keys in ascending order like [k1 k2 k3].
+// %+v prints tree structure where every node is printed as (key color left right) and empty child as -.
+// Precision limits number of printed keys (or nodes), for example %.10v. By default, at most fmtutil.DefaultLimit keys are printed.
+func (rbTree *RbTree[K]) Format(state fmt.State, verb rune) {
+	formatTree[K](state, verb, rbTree, rbTree.root, rbTree.sentinel, rbTree.len, func(node BBSTNode[K]) string {
+		return node.(*rbTreeNode[K]).color.String()
+	})
+}
+
+// Returns keys in ascending order like [k1 k2 k3], truncated after fmtutil.DefaultLimit keys
+func (avlTree *AvlTree[K]) String() string {
+	return fmt.Sprint(avlTree)
+}
+
+// Implements fmt.Formatter. %v and %s print keys in ascending order like [k1 k2 k3].
+// %+v prints tree structure where every node is printed as (key h=height left right) and empty child as -.
+// Precision limits number of printed keys (or nodes), for example %.10v. By default, at most fmtutil.DefaultLimit keys are printed.
+func (avlTree *AvlTree[K]) Format(state fmt.State, verb rune) {
+	formatTree[K](state, verb, avlTree, avlTree.root, avlTree.sentinel, avlTree.len, func(node BBSTNode[K]) string {
+		return fmt.Sprintf("h=%d", node.(*avlTreeNode[K]).height)
+	})
+}
+
+// Returns keys in ascending order like [k1 k2 k3], truncated after fmtutil.DefaultLimit keys
+func (rbTreeAugmented *RbTreeAugmented[K, A]) String() string {
+	return fmt.Sprint(rbTreeAugmented)
+}
+
+// Implements fmt.Formatter. %v and %s print keys in ascending order like [k1 k2 k3].
+// %+v prints tree structure where every node is printed as (key color aug=augmentedValue left right) and empty child as -.
+// Precision limits number of printed keys (or nodes), for example %.10v. By default, at most fmtutil.DefaultLimit keys are printed.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) Format(state fmt.State, verb rune) {
+	formatTree[K](state, verb, rbTreeAugmented, rbTreeAugmented.root, rbTreeAugmented.sentinel, rbTreeAugmented.len, func(node BBSTNode[K]) string {
+		augmentedNode := node.(*rbTreeNodeAugmented[K, A])
+		return fmt.Sprintf("%v aug=%v", augmentedNode.color, augmentedNode.augmentedValue)
+	})
+}
+
+type formattableTree[K any] interface {
+	Begin() OrderedSetForwardIterator[K]
+}
+
+func formatTree[K any](state fmt.State, verb rune, tree formattableTree[K], root, sentinel BBSTNode[K], len int64, attributes func(node BBSTNode[K]) string) {
+	if verb != 'v' && verb != 's' {
+		fmtutil.WriteBadVerb(state, verb, tree)
+		return
+	}
+	limit := fmtutil.Limit(state)
+	if state.Flag('+') {
+		if root == sentinel {
+			io.WriteString(state, "()")
+			return
+		}
+		writeSubtree[K](state, root, sentinel, attributes, &limit)
+		return
+	}
+	iterator := tree.Begin()
+	fmtutil.WriteSeq(state, "[", "]", len, limit, func(w io.Writer) bool {
+		key, ok := iterator.Key()
+		if ok {
+			fmt.Fprint(w, key)
+			iterator.Next()
+		}
+		return ok
+	})
+}
+
+// writes subtree rooted at node as (key attributes left right). budget is number of nodes that can still be printed; remaining subtrees are printed as ...
+func writeSubtree[K any](w io.Writer, node, sentinel BBSTNode[K], attributes func(node BBSTNode[K]) string, budget *int) {
+	if *budget <= 0 {
+		io.WriteString(w, "...")
+		return
+	}
+	*budget--
+	fmt.Fprintf(w, "(%v %s", node.GetKey(), attributes(node))
+	left, right := node.GetLeft(), node.GetRight()
+	if left != sentinel || right != sentinel {
+		for _, child := range [...]BBSTNode[K]{left, right} {
+			io.WriteString(w, " ")
+			if child == sentinel {
+				io.WriteString(w, "-")
+				continue
+			}
+			writeSubtree[K](w, child, sentinel, attributes, budget)
+		}
+	}
+	io.WriteString(w, ")")
+}
@@ -0,0 +1,72 @@
+package orderedset_test
+
+import (
+	"fmt"
+	"testing"
+
+	orderedset "github.com/storybehind/gocontainer/orderedset"
+)
+
+func TestOrderedSetFormat(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	rbTree := orderedset.NewRbTree[int](less)
+	avlTree := orderedset.NewAvlTree[int](less)
+	augmented := orderedset.NewRbTreeAugmented[int, int](less, func(node, sentinel orderedset.BBSTNodeAugmented[int, int]) int {
+		count := 1
+		for _, child := range []orderedset.BBSTNodeAugmented[int, int]{node.GetLeftAugmented(), node.GetRightAugmented()} {
+			if child != sentinel {
+				count += child.GetAugmentedValue()
+			}
+		}
+		return count
+	})
+	for _, format := range []string{"%v", "%+v"} {
+		for _, tree := range []any{rbTree, avlTree, augmented} {
+			exp := "[]"
+			if format == "%+v" {
+				exp = "()"
+			}
+			if s := fmt.Sprintf(format, tree); s != exp {
+				t.Errorf("Sprintf(%s) of empty %T = %s, exp = %s", format, tree, s, exp)
+			}
+		}
+	}
+
+	for _, key := range []int{1, 2, 3} {
+		rbTree.ReplaceOrInsert(key)
+		avlTree.ReplaceOrInsert(key)
+		augmented.ReplaceOrInsert(key)
+	}
+	avlTree.ReplaceOrInsert(4)
+	tests := []struct {
+		format string
+		tree   any
+		exp    string
+	}{
+		{"%v", rbTree, "[1 2 3]"},
+		{"%s", avlTree, "[1 2 3 4]"},
+		{"%.2v", rbTree, "[1 2 ...(1 more)]"},
+		{"%.0v", rbTree, "[...(3 more)]"},
+		{"%+v", rbTree, "(2 black (1 red) (3 red))"},
+		{"%+v", avlTree, "(2 h=3 (1 h=1) (3 h=2 - (4 h=1)))"},
+		{"%+.2v", avlTree, "(2 h=3 (1 h=1) ...)"},
+		{"%+v", augmented, "(2 black aug=3 (1 red aug=1) (3 red aug=1))"},
+		{"%d", rbTree, "%!d(*orderedset.RbTree[int])"},
+	}
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, test.tree); s != test.exp {
+			t.Errorf("Sprintf(%s) of %T = %s, exp = %s", test.format, test.tree, s, test.exp)
+		}
+	}
+	if s := rbTree.String(); s != "[1 2 3]" {
+		t.Errorf("String() = %s, exp = [1 2 3]", s)
+	}
+
+	large := orderedset.NewRbTree[int](less)
+	for key := 0; key < 1000; key++ {
+		large.ReplaceOrInsert(key)
+	}
+	if s := large.String(); len(s) > 1000 {
+		t.Errorf("String() of large tree is not truncated, len = %d", len(s))
+	}
+}
@@ -0,0 +1,58 @@
+package orderedset
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var errUnmarshalUninitialized = errors.New("orderedset: UnmarshalJSON on tree not created by constructor")
+
+// Encodes keys of the tree as JSON array in ascending order. Implements json.Marshaler.
+func (rbTree *RbTree[K]) MarshalJSON() ([]byte, error) {
+	return marshalKeys[K](rbTree.Begin(), rbTree.len)
+}
+
+// Decodes JSON array of keys into the tree, replacing its contents. Equal keys are deduplicated, keeping the last one.
+// Implements json.Unmarshaler. Tree must be created by NewRbTree since less function cannot be decoded.
+func (rbTree *RbTree[K]) UnmarshalJSON(data []byte) error {
+	if rbTree.less == nil {
+		return errUnmarshalUninitialized
+	}
+	var keys []K
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	rbTree.root = rbTree.sentinel
+	rbTree.len = 0
+	for _, key := range keys {
+		rbTree.ReplaceOrInsert(key)
+	}
+	return nil
+}
+
+// Encodes keys of the tree as JSON array in ascending order. Implements json.Marshaler.
+func (avlTree *AvlTree[K]) MarshalJSON() ([]byte, error) {
+	return marshalKeys[K](avlTree.Begin(), avlTree.len)
+}
+
+// Decodes JSON array of keys into the tree, replacing its contents. Equal keys are deduplicated, keeping the last one.
+// Implements json.Unmarshaler. Tree must be created by NewAvlTree since less function cannot be decoded.
+func (avlTree *AvlTree[K]) UnmarshalJSON(data []byte) error {
+	if avlTree.less == nil {
+		return errUnmarshalUninitialized
+	}
+	var keys []K
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	avlTree.root = avlTree.sentinel
+	avlTree.len = 0
+	for _, key := range keys {
+		avlTree.ReplaceOrInsert(key)
+	}
+	return nil
+}
+
+func marshalKeys[K any](iterator OrderedSetForwardIterator[K], len int64) ([]byte, error) {
+	return json.Marshal(collectKeys[K](iterator, len))
+}
@@ -0,0 +1,59 @@
+package orderedset_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	orderedset "github.com/storybehind/gocontainer/orderedset"
+)
+
+type jsonOrderedSet interface {
+	orderedset.OrderedSetI[int]
+	json.Marshaler
+	json.Unmarshaler
+}
+
+func testOrderedSetJSON(t *testing.T, os jsonOrderedSet, decoded jsonOrderedSet) {
+	for _, key := range []int{5, -3, 8, 0} {
+		os.ReplaceOrInsert(key)
+	}
+	data, err := json.Marshal(os)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v", err)
+	}
+	if string(data) != "[-3,0,5,8]" {
+		t.Errorf("Marshal() = %s, exp = [-3,0,5,8]", data)
+	}
+
+	decoded.ReplaceOrInsert(100)
+	if err := json.Unmarshal([]byte("[4, 2, 9, 2]"), decoded); err != nil {
+		t.Fatalf("Unmarshal() err = %v", err)
+	}
+	if decoded.Len() != 3 {
+		t.Errorf("Len() = %d, exp = 3", decoded.Len())
+	}
+	iterator := decoded.Begin()
+	for _, expKey := range []int{2, 4, 9} {
+		if key, ok := iterator.Key(); !ok || key != expKey {
+			t.Errorf("found key = %d, exp = %d", key, expKey)
+		}
+		iterator.Next()
+	}
+	if err := json.Unmarshal([]byte(`{"a": 1}`), decoded); err == nil {
+		t.Errorf("Unmarshal() of JSON object; exp error")
+	}
+	if decoded.Len() != 3 {
+		t.Errorf("Len() after failed Unmarshal = %d, exp = 3", decoded.Len())
+	}
+}
+
+func TestOrderedSetJSON(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	testOrderedSetJSON(t, orderedset.NewRbTree[int](less), orderedset.NewRbTree[int](less))
+	testOrderedSetJSON(t, orderedset.NewAvlTree[int](less), orderedset.NewAvlTree[int](less))
+
+	var uninitialized orderedset.RbTree[int]
+	if err := json.Unmarshal([]byte("[1]"), &uninitialized); err == nil {
+		t.Errorf("Unmarshal() into tree not created by constructor; exp error")
+	}
+}
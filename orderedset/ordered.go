@@ -0,0 +1,18 @@
+package orderedset
+
+import "cmp"
+
+// Returns instance of Red-Black Tree ordering keys ascending by cmp.Compare.
+func NewOrdered[K cmp.Ordered]() *RbTree[K] {
+	return NewRbTreeFunc[K](cmp.Compare[K])
+}
+
+// Returns instance of Red-Black Tree ordering keys ascending by cmp.Compare.
+func NewOrderedRbTree[K cmp.Ordered]() *RbTree[K] {
+	return NewRbTreeFunc[K](cmp.Compare[K])
+}
+
+// Returns instance of AVL Tree ordering keys ascending by cmp.Compare.
+func NewOrderedAvlTree[K cmp.Ordered]() *AvlTree[K] {
+	return NewAvlTreeFunc[K](cmp.Compare[K])
+}
@@ -0,0 +1,65 @@
+package orderedset_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	orderedset "github.com/storybehind/gocontainer/orderedset"
+)
+
+func TestOrderedConstructors(t *testing.T) {
+	testOrderedSet(t, orderedset.NewOrdered[int]())
+	testOrderedSet(t, orderedset.NewOrderedAvlTree[int]())
+	testOrderedSetForwardIterator(t, orderedset.NewOrderedRbTree[int]())
+	testOrderedSetReverseIterator(t, orderedset.NewOrderedAvlTree[int]())
+
+	// comparators may return any negative or positive number, not only -1 and 1
+	cmp := func(k1, k2 int) int { return (k1 - k2) * 1000 }
+	testOrderedSet(t, orderedset.NewFunc[int](cmp))
+	testOrderedSet(t, orderedset.NewAvlTreeFunc[int](cmp))
+	testOrderedSet(t, orderedset.NewRbTreeAugmentedFunc[int, int](cmp, countAugmentValue))
+
+	words := orderedset.NewOrderedAvlTree[string]()
+	for _, word := range strings.Fields("pear apple fig banana apple") {
+		words.ReplaceOrInsert(word)
+	}
+	if s := words.String(); s != "[apple banana fig pear]" {
+		t.Errorf("words = %s, exp = [apple banana fig pear]", s)
+	}
+
+	floats := orderedset.NewOrdered[float64]()
+	for _, f := range []float64{2, math.NaN(), -1, math.NaN()} {
+		floats.ReplaceOrInsert(f)
+	}
+	if first, _ := floats.Min(); floats.Len() != 3 || !math.IsNaN(first) {
+		t.Errorf("floats with NaN; Len() = %d, Min() = %v, exp = 3, NaN", floats.Len(), first)
+	}
+	if err := floats.Validate(); err != nil {
+		t.Errorf("Validate() err = %v", err)
+	}
+}
+
+func TestFuncConstructorComparisons(t *testing.T) {
+	lessCalls, cmpCalls := 0, 0
+	rbTree := orderedset.NewRbTree[int](func(k1, k2 int) bool {
+		lessCalls++
+		return k1 < k2
+	})
+	rbTreeFunc := orderedset.NewRbTreeFunc[int](func(k1, k2 int) int {
+		cmpCalls++
+		return k1 - k2
+	})
+	for key := 0; key < 1000; key++ {
+		rbTree.ReplaceOrInsert(key * 7919 % 1000)
+		rbTreeFunc.ReplaceOrInsert(key * 7919 % 1000)
+	}
+	for key := 0; key < 1000; key++ {
+		rbTree.Get(key)
+		rbTreeFunc.Get(key)
+	}
+	// less is called twice on most nodes visited by a search, cmp once
+	if 3*cmpCalls > 2*lessCalls {
+		t.Errorf("cmp calls = %d, less calls = %d, exp cmp calls to be much fewer", cmpCalls, lessCalls)
+	}
+}
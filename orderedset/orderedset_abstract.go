@@ -56,8 +56,33 @@ func New[K any](less func(k1, k2 K) bool) *RbTree[K] {
 	return NewRbTree[K](less)
 }
 
+// Returns instance of Red-Black Tree ordered by three-way comparison function as in cmp.Compare.
+func NewFunc[K any](cmp func(k1, k2 K) int) *RbTree[K] {
+	return NewRbTreeFunc[K](cmp)
+}
+
 type compare[K any] func(k1, k2 K) int
 
+// Returns three-way comparison derived from less
+func lessCompare[K any](less func(k1, k2 K) bool) compare[K] {
+	return func(k1, k2 K) int {
+		if less(k1, k2) {
+			return -1
+		}
+		if less(k2, k1) {
+			return 1
+		}
+		return 0
+	}
+}
+
+// Returns less derived from three-way comparison
+func compareLess[K any](cmp func(k1, k2 K) int) func(k1, k2 K) bool {
+	return func(k1, k2 K) bool {
+		return cmp(k1, k2) < 0
+	}
+}
+
 func searchNode[K any](node BBSTNode[K], key K, cmp compare[K], sentinel BBSTNode[K]) BBSTNode[K] {
 	var curNode BBSTNode[K] = node
 	for curNode != sentinel {
@@ -65,7 +90,7 @@ func searchNode[K any](node BBSTNode[K], key K, cmp compare[K], sentinel BBSTNod
 		if compare == 0 {
 			return curNode
 		}
-		if compare == -1 {
+		if compare < 0 {
 			curNode = curNode.GetLeft()
 		} else {
 			curNode = curNode.GetRight()
@@ -98,7 +123,7 @@ func searchGreaterThanOrEqualNode[K any](node BBSTNode[K], key K, cmp compare[K]
 			greaterThanOrEqualKeyNode = curNode
 			break
 		}
-		if compare == 1 {
+		if compare > 0 {
 			curNode = curNode.GetRight()
 			continue
 		}
@@ -132,7 +157,7 @@ func searchLowerThanOrEqualNode[K any](node BBSTNode[K], key K, cmp compare[K],
 			lowerThanOrEqualKeyNode = curNode
 			break
 		}
-		if compare == -1 {
+		if compare < 0 {
 			curNode = curNode.GetLeft()
 			continue
 		}
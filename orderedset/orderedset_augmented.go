@@ -1,5 +1,7 @@
 package orderedset
 
+import "github.com/storybehind/gocontainer/codec"
+
 // Balanced Binary Search Node interface with support for augmentation
 type BBSTNodeAugmented[K, A any] interface {
 	BBSTNode[K]
@@ -52,6 +54,11 @@ func (rbTreeNodeAugmented *rbTreeNodeAugmented[K, A]) GetAugmentedValue() A {
 	return rbTreeNodeAugmented.augmentedValue
 }
 
+// Returns true if node is colored red
+func (rbTreeNodeAugmented *rbTreeNodeAugmented[K, A]) IsRed() bool {
+	return rbTreeNodeAugmented.color == RED
+}
+
 // Maintains unique set of keys and invariant of node's augmented value. 
 // Supports insertion, deletion of keys in O(t * log n) time where n is number of keys in the set and t is time required to maintain node's invariant.
 // Search operation takes O(log n) time.
@@ -63,6 +70,8 @@ type RbTreeAugmented[K, A any] struct {
 	less     func(k1, k2 K) bool
 	cmp      compare[K]
 	len      int64
+	// codec of keys used by binary encoding. nil means codec.Default
+	codec    codec.Codec[K]
 }
 
 // Returns instance of Red-Black Tree.
@@ -74,6 +83,16 @@ type RbTreeAugmented[K, A any] struct {
 // First argument gives node pointer whose invariant has to be maintained.
 // Second argument gives sentinel node pointer (can be thought of nil leaf nodes or root's parent)
 func NewRbTreeAugmented[K, A any](less func(k1, k2 K) bool, updateAugmentValue func(BBSTNodeAugmented[K, A], BBSTNodeAugmented[K, A]) A) *RbTreeAugmented[K, A] {
+	return newRbTreeAugmented[K, A](less, lessCompare[K](less), updateAugmentValue)
+}
+
+// Returns instance of Red-Black Tree with augmentation, ordered by three-way comparison function as in cmp.Compare.
+// updateAugmentValue is same as in NewRbTreeAugmented
+func NewRbTreeAugmentedFunc[K, A any](cmp func(k1, k2 K) int, updateAugmentValue func(BBSTNodeAugmented[K, A], BBSTNodeAugmented[K, A]) A) *RbTreeAugmented[K, A] {
+	return newRbTreeAugmented[K, A](compareLess[K](cmp), cmp, updateAugmentValue)
+}
+
+func newRbTreeAugmented[K, A any](less func(k1, k2 K) bool, cmp compare[K], updateAugmentValue func(BBSTNodeAugmented[K, A], BBSTNodeAugmented[K, A]) A) *RbTreeAugmented[K, A] {
 	sentinel := &rbTreeNodeAugmented[K, A] {
 		color: BLACK,
 	}
@@ -82,15 +101,7 @@ func NewRbTreeAugmented[K, A any](less func(k1, k2 K) bool, updateAugmentValue f
 		sentinel: sentinel,
 		less:     less,
 		updateAugmentValue: updateAugmentValue,
-		cmp: func(k1, k2 K) int {
-			if less(k1, k2) {
-				return -1
-			}
-			if less(k2, k1) {
-				return 1
-			}
-			return 0
-		},
+		cmp:      cmp,
 		len: 0,
 	}
 }
@@ -180,15 +191,19 @@ func (rbTreeAugmented *RbTreeAugmented[K, A]) GetSentinel() BBSTNodeAugmented[K,
 func (rbTreeAugmented *RbTreeAugmented[K, A]) ReplaceOrInsert(key K) (_ K, _ bool) {
 	var y *rbTreeNodeAugmented[K, A] = rbTreeAugmented.sentinel
 	x := rbTreeAugmented.root
+	// result of last comparison decides on which side of y the key is inserted
+	var compare int
 	for x != rbTreeAugmented.sentinel {
 		y = x
-		if rbTreeAugmented.less(key, x.key) {
+		compare = rbTreeAugmented.cmp(key, x.key)
+		if compare < 0 {
 			x = x.left
-		} else if rbTreeAugmented.less(x.key, key) {
+		} else if compare > 0 {
 			x = x.right
 		} else {
 			var prevKey K = x.key
 			x.key = key
+			rbTreeAugmented.updateAugmentedPath(x)
 			return prevKey, true
 		}
 	}
@@ -196,7 +211,7 @@ func (rbTreeAugmented *RbTreeAugmented[K, A]) ReplaceOrInsert(key K) (_ K, _ boo
 	z.parent = y
 	if y == rbTreeAugmented.sentinel {
 		rbTreeAugmented.root = z
-	} else if rbTreeAugmented.less(key, y.key) {
+	} else if compare < 0 {
 		y.left = z
 	} else {
 		y.right = z
@@ -206,6 +221,7 @@ func (rbTreeAugmented *RbTreeAugmented[K, A]) ReplaceOrInsert(key K) (_ K, _ boo
 	z.color = RED
 	z.key = key
 	rbTreeAugmented.len++
+	rbTreeAugmented.updateAugmentedPath(z)
 	rbTreeAugmented.replaceOrInsertFixup(z)
 	return
 }
@@ -287,6 +303,14 @@ func (rbTreeAugmented *RbTreeAugmented[K, A]) rightRotate(y *rbTreeNodeAugmented
 	x.augmentedValue = rbTreeAugmented.updateAugmentValue(x, rbTreeAugmented.sentinel)
 }
 
+// Recomputes augmented value of node and all of its ancestors up to the root
+func (rbTreeAugmented *RbTreeAugmented[K, A]) updateAugmentedPath(node *rbTreeNodeAugmented[K, A]) {
+	for node != rbTreeAugmented.sentinel {
+		node.augmentedValue = rbTreeAugmented.updateAugmentValue(node, rbTreeAugmented.sentinel)
+		node = node.parent
+	}
+}
+
 // Delete the key in the tree and return its value.
 // If key is not found in the tree, returns (zeroValue, false)
 func (rbTreeAugmented *RbTreeAugmented[K, A]) Delete(key K) (_ K, _ bool) {
@@ -366,6 +390,7 @@ func (rbTreeAugmented *RbTreeAugmented[K, A]) delete(z *rbTreeNodeAugmented[K, A
 	z.left = nil
 	z.right = nil
 	z.parent = nil
+	rbTreeAugmented.updateAugmentedPath(x.parent)
 	if yOriginalColor == BLACK {
 		rbTreeAugmented.deleteFixup(x)
 	}
@@ -0,0 +1,108 @@
+package orderedsettest
+
+import "github.com/storybehind/gocontainer/orderedset"
+
+// adapts set of Key to set of int, so that it can be checked by containertest
+type intSet struct {
+	set orderedset.OrderedSetI[Key]
+}
+
+func toInt(key Key, ok bool) (int, bool) {
+	return key.Value, ok
+}
+
+func (s intSet) Get(key int) (int, bool) {
+	return toInt(s.set.Get(Key{Value: key}))
+}
+
+func (s intSet) GetGreater(key int) (int, bool) {
+	return toInt(s.set.GetGreater(Key{Value: key}))
+}
+
+func (s intSet) GetGreaterThanOrEqual(key int) (int, bool) {
+	return toInt(s.set.GetGreaterThanOrEqual(Key{Value: key}))
+}
+
+func (s intSet) GetLower(key int) (int, bool) {
+	return toInt(s.set.GetLower(Key{Value: key}))
+}
+
+func (s intSet) GetLowerThanOrEqual(key int) (int, bool) {
+	return toInt(s.set.GetLowerThanOrEqual(Key{Value: key}))
+}
+
+func (s intSet) Max() (int, bool) {
+	return toInt(s.set.Max())
+}
+
+func (s intSet) Min() (int, bool) {
+	return toInt(s.set.Min())
+}
+
+func (s intSet) Len() int64 {
+	return s.set.Len()
+}
+
+func (s intSet) ReplaceOrInsert(key int) (int, bool) {
+	return toInt(s.set.ReplaceOrInsert(Key{Value: key}))
+}
+
+func (s intSet) Delete(key int) (int, bool) {
+	return toInt(s.set.Delete(Key{Value: key}))
+}
+
+func (s intSet) DeleteMax() (int, bool) {
+	return toInt(s.set.DeleteMax())
+}
+
+func (s intSet) DeleteMin() (int, bool) {
+	return toInt(s.set.DeleteMin())
+}
+
+func (s intSet) Begin() orderedset.OrderedSetForwardIterator[int] {
+	return intIterator{s.set.Begin()}
+}
+
+func (s intSet) Rbegin() orderedset.OrderedSetReverseIterator[int] {
+	return intReverseIterator{s.set.Rbegin()}
+}
+
+// calls Validate of the adapted set if it has one
+func (s intSet) Validate() error {
+	if validator, ok := s.set.(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+type intIterator struct {
+	iterator orderedset.OrderedSetForwardIterator[Key]
+}
+
+func (it intIterator) Next() (int, bool) {
+	return toInt(it.iterator.Next())
+}
+
+func (it intIterator) Key() (int, bool) {
+	return toInt(it.iterator.Key())
+}
+
+func (it intIterator) Remove() (int, bool) {
+	return toInt(it.iterator.Remove())
+}
+
+type intReverseIterator struct {
+	iterator orderedset.OrderedSetReverseIterator[Key]
+}
+
+func (it intReverseIterator) Prev() (int, bool) {
+	return toInt(it.iterator.Prev())
+}
+
+func (it intReverseIterator) Key() (int, bool) {
+	return toInt(it.iterator.Key())
+}
+
+func (it intReverseIterator) Remove() (int, bool) {
+	return toInt(it.iterator.Remove())
+}
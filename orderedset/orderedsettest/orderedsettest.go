@@ -0,0 +1,361 @@
+// Package orderedsettest provides a conformance test suite for implementations of orderedset.OrderedSetI.
+//
+// An implementation passing Run behaves like RbTree and AvlTree for every method of OrderedSetI, the interface orderedmap keeps its keys in:
+//
+//	func TestMySet(t *testing.T) {
+//		orderedsettest.Run(t, func(less func(k1, k2 orderedsettest.Key) bool) orderedset.OrderedSetI[orderedsettest.Key] {
+//			return NewMySet[orderedsettest.Key](less)
+//		})
+//	}
+package orderedsettest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/storybehind/gocontainer/containertest"
+	"github.com/storybehind/gocontainer/orderedset"
+)
+
+// Key is the key type sets are tested with. Keys are ordered by Value only, so keys with equal Value and different Tag are equal keys.
+// Tag lets the suite tell which of equal keys a set holds.
+type Key struct {
+	Value int
+	Tag   int
+}
+
+// Less orders keys by Value. It is the less function passed to Factory.
+func Less(k1, k2 Key) bool {
+	return k1.Value < k2.Value
+}
+
+// Factory returns a new empty set ordered by less.
+type Factory func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key]
+
+// Run tests the OrderedSetI contract as subtests of t, each on a new set returned by factory:
+// behaviour of empty set, insertion and replace semantics, deletion, neighbour queries, Min and Max, forward and reverse iterators including iterator Remove,
+// and random operation sequences checked against a sorted slice. If the set implements Validate() error, it is called after every random operation.
+func Run(t *testing.T, factory Factory) {
+	newSet := func(t *testing.T, values ...int) orderedset.OrderedSetI[Key] {
+		t.Helper()
+		set := factory(Less)
+		for _, value := range values {
+			if _, replaced := set.ReplaceOrInsert(Key{Value: value}); replaced {
+				t.Fatalf("ReplaceOrInsert(%d) of new key replaced a key", value)
+			}
+		}
+		if set.Len() != int64(len(values)) {
+			t.Fatalf("Len() = %d after inserting %d keys", set.Len(), len(values))
+		}
+		return set
+	}
+	t.Run("Empty", func(t *testing.T) {
+		testEmpty(t, newSet(t))
+		// set emptied by deletions behaves as new set
+		set := newSet(t, 3, 1, 2)
+		for _, value := range []int{1, 2, 3} {
+			set.Delete(Key{Value: value})
+		}
+		testEmpty(t, set)
+	})
+	t.Run("Replace", func(t *testing.T) {
+		testReplace(t, newSet(t, 1, 5, 9))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		testDelete(t, newSet(t, 5, 2, 3, 11, 7))
+	})
+	t.Run("Neighbours", func(t *testing.T) {
+		testNeighbours(t, newSet(t, 5, 2, 3, 11, 7))
+	})
+	t.Run("DeleteMinMax", func(t *testing.T) {
+		testDeleteMinMax(t, newSet(t, 4, 8, 1, 6, 3, 7, 2, 5))
+	})
+	t.Run("ForwardIterator", func(t *testing.T) {
+		testForwardIterator(t, newSet(t, 4, 2, 5, 1, 3))
+	})
+	t.Run("ReverseIterator", func(t *testing.T) {
+		testReverseIterator(t, newSet(t, 4, 2, 5, 1, 3))
+	})
+	t.Run("IteratorRemovePanics", func(t *testing.T) {
+		testIteratorRemovePanics(t, newSet(t, 1, 2))
+	})
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20; i++ {
+			containertest.CheckOrderedSet(t, intSet{newSet(t)}, containertest.RandomOps(r, 500))
+		}
+	})
+}
+
+// asserts that query returned (exp, expOk), comparing both Value and Tag
+func checkKey(t *testing.T, name string, got Key, ok bool, exp Key, expOk bool) {
+	t.Helper()
+	if ok != expOk || got != exp {
+		t.Errorf("%s = (%+v, %v), exp = (%+v, %v)", name, got, ok, exp, expOk)
+	}
+}
+
+func testEmpty(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	if set.Len() != 0 {
+		t.Errorf("Len() = %d, exp = 0", set.Len())
+	}
+	queries := map[string]func(key Key) (Key, bool){
+		"Get":                   set.Get,
+		"GetGreater":            set.GetGreater,
+		"GetGreaterThanOrEqual": set.GetGreaterThanOrEqual,
+		"GetLower":              set.GetLower,
+		"GetLowerThanOrEqual":   set.GetLowerThanOrEqual,
+		"Delete":                set.Delete,
+	}
+	for name, query := range queries {
+		got, ok := query(Key{})
+		checkKey(t, name+"(0)", got, ok, Key{}, false)
+	}
+	for name, query := range map[string]func() (Key, bool){"Min": set.Min, "Max": set.Max, "DeleteMin": set.DeleteMin, "DeleteMax": set.DeleteMax} {
+		got, ok := query()
+		checkKey(t, name+"()", got, ok, Key{}, false)
+	}
+	iterator := set.Begin()
+	got, ok := iterator.Key()
+	checkKey(t, "Begin().Key()", got, ok, Key{}, false)
+	got, ok = iterator.Next()
+	checkKey(t, "Begin().Next()", got, ok, Key{}, false)
+	reverseIterator := set.Rbegin()
+	got, ok = reverseIterator.Key()
+	checkKey(t, "Rbegin().Key()", got, ok, Key{}, false)
+	got, ok = reverseIterator.Prev()
+	checkKey(t, "Rbegin().Prev()", got, ok, Key{}, false)
+	if set.Len() != 0 {
+		t.Errorf("Len() = %d after queries on empty set, exp = 0", set.Len())
+	}
+}
+
+func testReplace(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	// replacing returns the key held by the set and stores the new one
+	got, ok := set.ReplaceOrInsert(Key{Value: 5, Tag: 1})
+	checkKey(t, "ReplaceOrInsert({5 1})", got, ok, Key{Value: 5}, true)
+	got, ok = set.ReplaceOrInsert(Key{Value: 5, Tag: 2})
+	checkKey(t, "ReplaceOrInsert({5 2})", got, ok, Key{Value: 5, Tag: 1}, true)
+	if set.Len() != 3 {
+		t.Errorf("Len() = %d after replacing, exp = 3", set.Len())
+	}
+	// queries return the stored key rather than the queried one
+	got, ok = set.Get(Key{Value: 5, Tag: 9})
+	checkKey(t, "Get({5 9})", got, ok, Key{Value: 5, Tag: 2}, true)
+	got, ok = set.GetGreater(Key{Value: 1, Tag: 9})
+	checkKey(t, "GetGreater({1 9})", got, ok, Key{Value: 5, Tag: 2}, true)
+	got, ok = set.GetLowerThanOrEqual(Key{Value: 6, Tag: 9})
+	checkKey(t, "GetLowerThanOrEqual({6 9})", got, ok, Key{Value: 5, Tag: 2}, true)
+	iterator := set.Begin()
+	iterator.Next()
+	got, ok = iterator.Key()
+	checkKey(t, "iterator Key()", got, ok, Key{Value: 5, Tag: 2}, true)
+	got, ok = set.Delete(Key{Value: 5, Tag: 9})
+	checkKey(t, "Delete({5 9})", got, ok, Key{Value: 5, Tag: 2}, true)
+	if set.Len() != 2 {
+		t.Errorf("Len() = %d after deleting, exp = 2", set.Len())
+	}
+	got, ok = set.ReplaceOrInsert(Key{Value: 5, Tag: 3})
+	checkKey(t, "ReplaceOrInsert({5 3}) after Delete", got, ok, Key{}, false)
+}
+
+func testDelete(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	got, ok := set.Delete(Key{Value: 4})
+	checkKey(t, "Delete(4) of absent key", got, ok, Key{}, false)
+	for i, value := range []int{5, 2, 11, 3, 7} {
+		got, ok = set.Delete(Key{Value: value})
+		checkKey(t, "Delete", got, ok, Key{Value: value}, true)
+		if set.Len() != int64(4-i) {
+			t.Errorf("Len() = %d after deleting %d keys, exp = %d", set.Len(), i+1, 4-i)
+		}
+		got, ok = set.Get(Key{Value: value})
+		checkKey(t, "Get of deleted key", got, ok, Key{}, false)
+		got, ok = set.Delete(Key{Value: value})
+		checkKey(t, "Delete of deleted key", got, ok, Key{}, false)
+	}
+}
+
+func testNeighbours(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	// set holds 2, 3, 5, 7, 11; -1 stands for no key
+	tests := []struct {
+		name  string
+		query func(key Key) (Key, bool)
+		key   int
+		exp   int
+	}{
+		{"Get", set.Get, 1, -1},
+		{"Get", set.Get, 2, 2},
+		{"Get", set.Get, 11, 11},
+		{"Get", set.Get, 6, -1},
+		{"GetGreater", set.GetGreater, 0, 2},
+		{"GetGreater", set.GetGreater, 2, 3},
+		{"GetGreater", set.GetGreater, 6, 7},
+		{"GetGreater", set.GetGreater, 11, -1},
+		{"GetGreaterThanOrEqual", set.GetGreaterThanOrEqual, 0, 2},
+		{"GetGreaterThanOrEqual", set.GetGreaterThanOrEqual, 5, 5},
+		{"GetGreaterThanOrEqual", set.GetGreaterThanOrEqual, 8, 11},
+		{"GetGreaterThanOrEqual", set.GetGreaterThanOrEqual, 12, -1},
+		{"GetLower", set.GetLower, 1, -1},
+		{"GetLower", set.GetLower, 2, -1},
+		{"GetLower", set.GetLower, 5, 3},
+		{"GetLower", set.GetLower, 6, 5},
+		{"GetLower", set.GetLower, 20, 11},
+		{"GetLowerThanOrEqual", set.GetLowerThanOrEqual, 1, -1},
+		{"GetLowerThanOrEqual", set.GetLowerThanOrEqual, 2, 2},
+		{"GetLowerThanOrEqual", set.GetLowerThanOrEqual, 10, 7},
+		{"GetLowerThanOrEqual", set.GetLowerThanOrEqual, 11, 11},
+	}
+	for _, test := range tests {
+		got, ok := test.query(Key{Value: test.key})
+		exp := Key{Value: test.exp}
+		if test.exp < 0 {
+			exp = Key{}
+		}
+		checkKey(t, fmt.Sprintf("%s(%d)", test.name, test.key), got, ok, exp, test.exp >= 0)
+	}
+	got, ok := set.Min()
+	checkKey(t, "Min()", got, ok, Key{Value: 2}, true)
+	got, ok = set.Max()
+	checkKey(t, "Max()", got, ok, Key{Value: 11}, true)
+	if set.Len() != 5 {
+		t.Errorf("Len() = %d after queries, exp = 5", set.Len())
+	}
+}
+
+func testDeleteMinMax(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	// set holds 1..8, deleted alternately from both ends
+	low, high := 1, 8
+	for low <= high {
+		got, ok := set.DeleteMin()
+		checkKey(t, "DeleteMin()", got, ok, Key{Value: low}, true)
+		low++
+		got, ok = set.DeleteMax()
+		checkKey(t, "DeleteMax()", got, ok, Key{Value: high}, true)
+		high--
+		if low <= high {
+			got, ok = set.Min()
+			checkKey(t, "Min()", got, ok, Key{Value: low}, true)
+			got, ok = set.Max()
+			checkKey(t, "Max()", got, ok, Key{Value: high}, true)
+		}
+	}
+	if set.Len() != 0 {
+		t.Errorf("Len() = %d, exp = 0", set.Len())
+	}
+}
+
+func testForwardIterator(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	iterator := set.Begin()
+	for value := 1; value <= 5; value++ {
+		got, ok := iterator.Key()
+		checkKey(t, "Key()", got, ok, Key{Value: value}, true)
+		got, ok = iterator.Next()
+		exp := Key{Value: value + 1}
+		if value == 5 {
+			exp = Key{}
+		}
+		checkKey(t, "Next()", got, ok, exp, value < 5)
+	}
+	got, ok := iterator.Next()
+	checkKey(t, "Next() on completed iterator", got, ok, Key{}, false)
+	got, ok = iterator.Key()
+	checkKey(t, "Key() on completed iterator", got, ok, Key{}, false)
+
+	// Remove returns next key and leaves the iterator on it
+	iterator = set.Begin()
+	iterator.Next()
+	got, ok = iterator.Remove()
+	checkKey(t, "Remove() of 2", got, ok, Key{Value: 3}, true)
+	got, ok = iterator.Key()
+	checkKey(t, "Key() after Remove()", got, ok, Key{Value: 3}, true)
+	iterator.Next()
+	got, ok = iterator.Remove()
+	checkKey(t, "Remove() of 4", got, ok, Key{Value: 5}, true)
+	got, ok = iterator.Remove()
+	checkKey(t, "Remove() of greatest key", got, ok, Key{}, false)
+	checkValues(t, set, 1, 3)
+
+	// removing every key through iterator empties the set
+	iterator = set.Begin()
+	for _, ok := iterator.Key(); ok; _, ok = iterator.Remove() {
+	}
+	checkValues(t, set)
+}
+
+func testReverseIterator(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	iterator := set.Rbegin()
+	for value := 5; value >= 1; value-- {
+		got, ok := iterator.Key()
+		checkKey(t, "Key()", got, ok, Key{Value: value}, true)
+		got, ok = iterator.Prev()
+		exp := Key{Value: value - 1}
+		if value == 1 {
+			exp = Key{}
+		}
+		checkKey(t, "Prev()", got, ok, exp, value > 1)
+	}
+	got, ok := iterator.Prev()
+	checkKey(t, "Prev() on completed iterator", got, ok, Key{}, false)
+	got, ok = iterator.Key()
+	checkKey(t, "Key() on completed iterator", got, ok, Key{}, false)
+
+	// Remove returns next smaller key and leaves the iterator on it
+	iterator = set.Rbegin()
+	iterator.Prev()
+	got, ok = iterator.Remove()
+	checkKey(t, "Remove() of 4", got, ok, Key{Value: 3}, true)
+	got, ok = iterator.Key()
+	checkKey(t, "Key() after Remove()", got, ok, Key{Value: 3}, true)
+	iterator.Prev()
+	got, ok = iterator.Remove()
+	checkKey(t, "Remove() of 2", got, ok, Key{Value: 1}, true)
+	got, ok = iterator.Remove()
+	checkKey(t, "Remove() of smallest key", got, ok, Key{}, false)
+	checkValues(t, set, 3, 5)
+
+	iterator = set.Rbegin()
+	for _, ok := iterator.Key(); ok; _, ok = iterator.Remove() {
+	}
+	checkValues(t, set)
+}
+
+func testIteratorRemovePanics(t *testing.T, set orderedset.OrderedSetI[Key]) {
+	checkPanics := func(name string, remove func() (Key, bool)) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s on completed iterator did not panic", name)
+			}
+		}()
+		remove()
+	}
+	iterator := set.Begin()
+	for _, ok := iterator.Key(); ok; _, ok = iterator.Next() {
+	}
+	checkPanics("Remove()", iterator.Remove)
+	reverseIterator := set.Rbegin()
+	for _, ok := reverseIterator.Key(); ok; _, ok = reverseIterator.Prev() {
+	}
+	checkPanics("reverse Remove()", reverseIterator.Remove)
+	checkValues(t, set, 1, 2)
+}
+
+// checks that set holds exactly keys with the given values in ascending order
+func checkValues(t *testing.T, set orderedset.OrderedSetI[Key], values ...int) {
+	t.Helper()
+	if set.Len() != int64(len(values)) {
+		t.Errorf("Len() = %d, exp = %d", set.Len(), len(values))
+	}
+	iterator := set.Begin()
+	for _, value := range values {
+		if got, ok := iterator.Key(); !ok || got.Value != value {
+			t.Errorf("iterated key = (%+v, %v), exp = %d", got, ok, value)
+			return
+		}
+		iterator.Next()
+	}
+	if got, ok := iterator.Key(); ok {
+		t.Errorf("unexpected key %+v after last key", got)
+	}
+}
@@ -0,0 +1,59 @@
+package orderedsettest_test
+
+import (
+	"testing"
+
+	"github.com/storybehind/gocontainer/orderedset"
+	"github.com/storybehind/gocontainer/orderedset/orderedsettest"
+	"github.com/storybehind/gocontainer/orderedset/variants"
+)
+
+type Key = orderedsettest.Key
+
+func TestRbTree(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return orderedset.NewRbTree[Key](less)
+	})
+}
+
+func TestAvlTree(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return orderedset.NewAvlTree[Key](less)
+	})
+}
+
+func TestRbTreeAugmented(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return orderedset.NewRbTreeAugmented[Key, int](less, func(node, sentinel orderedset.BBSTNodeAugmented[Key, int]) int {
+			return node.GetKey().Value
+		})
+	})
+}
+
+func TestOrderStatisticsTree(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return variants.NewOrderStatisticsTree[Key](less)
+	})
+}
+
+func keyCompare(k1, k2 Key) int {
+	return k1.Value - k2.Value
+}
+
+func TestRbTreeFunc(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return orderedset.NewRbTreeFunc[Key](keyCompare)
+	})
+}
+
+func TestAvlTreeFunc(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return orderedset.NewAvlTreeFunc[Key](keyCompare)
+	})
+}
+
+func TestOrderStatisticsTreeFunc(t *testing.T) {
+	orderedsettest.Run(t, func(less func(k1, k2 Key) bool) orderedset.OrderedSetI[Key] {
+		return variants.NewOrderStatisticsTreeFunc[Key](keyCompare)
+	})
+}
@@ -1,5 +1,7 @@
 package orderedset
 
+import "github.com/storybehind/gocontainer/codec"
+
 type rbTreeNode[K any] struct {
 	left, right, parent *rbTreeNode[K]
 	key                 K
@@ -22,6 +24,11 @@ func (rbTreeNode *rbTreeNode[K]) GetKey() K {
 	return rbTreeNode.key
 }
 
+// Returns true if node is colored red
+func (rbTreeNode *rbTreeNode[K]) IsRed() bool {
+	return rbTreeNode.color == RED
+}
+
 // color type details color of a node
 type color byte
 
@@ -38,6 +45,8 @@ type RbTree[K any] struct {
 	less     func(k1, k2 K) bool
 	cmp      compare[K]
 	len      int64
+	// codec of keys used by binary encoding. nil means codec.Default
+	codec codec.Codec[K]
 }
 
 // Returns instance of Red-Black Tree.
@@ -45,6 +54,15 @@ type RbTree[K any] struct {
 // k1 precedes k2 if and only if Less(k1, k2) return true.
 // k1 equals k2 if and only if !Less(k1, k2) && !Less(k2, k1) holds true.
 func NewRbTree[K any](less func(k1, k2 K) bool) *RbTree[K] {
+	return newRbTree[K](less, lessCompare[K](less))
+}
+
+// Returns instance of Red-Black Tree ordered by three-way comparison function as in cmp.Compare.
+func NewRbTreeFunc[K any](cmp func(k1, k2 K) int) *RbTree[K] {
+	return newRbTree[K](compareLess[K](cmp), cmp)
+}
+
+func newRbTree[K any](less func(k1, k2 K) bool, cmp compare[K]) *RbTree[K] {
 	sentinel := &rbTreeNode[K]{
 		color: BLACK,
 	}
@@ -52,16 +70,8 @@ func NewRbTree[K any](less func(k1, k2 K) bool) *RbTree[K] {
 		root:     sentinel,
 		sentinel: sentinel,
 		less:     less,
-		cmp: func(k1, k2 K) int {
-			if less(k1, k2) {
-				return -1
-			}
-			if less(k2, k1) {
-				return 1
-			}
-			return 0
-		},
-		len: 0,
+		cmp:      cmp,
+		len:      0,
 	}
 }
 
@@ -133,6 +143,16 @@ func (rbtree *RbTree[K]) Len() int64 {
 	return rbtree.len
 }
 
+// Returns root node of the tree.
+func (rbTree *RbTree[K]) GetRoot() BBSTNode[K] {
+	return rbTree.root
+}
+
+// Returns sentinel node of the tree (can be thought of nil leaf nodes or root's parent)
+func (rbTree *RbTree[K]) GetSentinel() BBSTNode[K] {
+	return rbTree.sentinel
+}
+
 // ReplaceOrInsert adds the given key to the tree.
 // If a key in the tree already equals the given one, it is removed from the tree and returned, and the second return value is true.
 // Otherwise, (zeroValue, false)
@@ -140,11 +160,14 @@ func (rbtree *RbTree[K]) Len() int64 {
 func (rbTree *RbTree[K]) ReplaceOrInsert(key K) (_ K, _ bool) {
 	var y *rbTreeNode[K] = rbTree.sentinel
 	x := rbTree.root
+	// result of last comparison decides on which side of y the key is inserted
+	var compare int
 	for x != rbTree.sentinel {
 		y = x
-		if rbTree.less(key, x.key) {
+		compare = rbTree.cmp(key, x.key)
+		if compare < 0 {
 			x = x.left
-		} else if rbTree.less(x.key, key) {
+		} else if compare > 0 {
 			x = x.right
 		} else {
 			var prevKey K = x.key
@@ -156,7 +179,7 @@ func (rbTree *RbTree[K]) ReplaceOrInsert(key K) (_ K, _ bool) {
 	z.parent = y
 	if y == rbTree.sentinel {
 		rbTree.root = z
-	} else if rbTree.less(key, y.key) {
+	} else if compare < 0 {
 		y.left = z
 	} else {
 		y.right = z
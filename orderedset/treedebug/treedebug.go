@@ -0,0 +1,155 @@
+// Package treedebug renders trees of orderedset as Graphviz DOT or ASCII art to inspect their shape while debugging.
+//
+// Trees are walked through BBSTNode starting from root and sentinel, as returned by GetRoot and GetSentinel of
+// RbTree, AvlTree and RbTreeAugmented. Nodes of red-black trees are rendered with their color, nodes of AVL trees
+// with their height and, through the Augmented variants, nodes of augmented trees with their augmented value.
+package treedebug
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/storybehind/gocontainer/orderedset"
+)
+
+// implemented by nodes of RbTree and RbTreeAugmented
+type coloredNode interface {
+	IsRed() bool
+}
+
+// implemented by nodes of AvlTree
+type heightNode interface {
+	GetHeight() int64
+}
+
+// WriteDOT writes tree rooted at root as Graphviz digraph to w.
+// Red-black nodes are filled with their color. Missing children of a node with one child are drawn as points to keep left and right apart.
+func WriteDOT[K any](w io.Writer, root, sentinel orderedset.BBSTNode[K]) error {
+	return writeDOT[K](w, root, sentinel, nil)
+}
+
+// WriteDOTAugmented is like WriteDOT and additionally labels every node with its augmented value.
+func WriteDOTAugmented[K, A any](w io.Writer, root, sentinel orderedset.BBSTNodeAugmented[K, A]) error {
+	return writeDOT[K](w, root, sentinel, augmentedValue[K, A])
+}
+
+// WriteASCII writes tree rooted at root to w, one node per line, with children indented below their parent (left child first).
+// Every line holds key of node followed by its color or height. Missing child of a node with one child is written as -.
+func WriteASCII[K any](w io.Writer, root, sentinel orderedset.BBSTNode[K]) error {
+	return writeASCII[K](w, root, sentinel, nil)
+}
+
+// WriteASCIIAugmented is like WriteASCII and additionally writes augmented value of every node as aug=value.
+func WriteASCIIAugmented[K, A any](w io.Writer, root, sentinel orderedset.BBSTNodeAugmented[K, A]) error {
+	return writeASCII[K](w, root, sentinel, augmentedValue[K, A])
+}
+
+func augmentedValue[K, A any](node orderedset.BBSTNode[K]) string {
+	return fmt.Sprintf("aug=%v", node.(orderedset.BBSTNodeAugmented[K, A]).GetAugmentedValue())
+}
+
+// records first write error so that rendering code does not have to check every write
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
+
+// returns attributes of node other than its color such as h=2 or aug=5
+func attributes[K any](node orderedset.BBSTNode[K], extra func(orderedset.BBSTNode[K]) string) []string {
+	var attrs []string
+	if hn, ok := node.(heightNode); ok {
+		attrs = append(attrs, fmt.Sprintf("h=%d", hn.GetHeight()))
+	}
+	if extra != nil {
+		attrs = append(attrs, extra(node))
+	}
+	return attrs
+}
+
+func writeDOT[K any](w io.Writer, root, sentinel orderedset.BBSTNode[K], extra func(orderedset.BBSTNode[K]) string) error {
+	ew := &errWriter{w: w}
+	ew.printf("digraph tree {\n\tnode [shape=box];\n")
+	if root != sentinel {
+		id := 0
+		writeDOTNode[K](ew, root, sentinel, extra, &id)
+	}
+	ew.printf("}\n")
+	return ew.err
+}
+
+// writes node and its subtree, returning DOT identifier of node
+func writeDOTNode[K any](ew *errWriter, node, sentinel orderedset.BBSTNode[K], extra func(orderedset.BBSTNode[K]) string, id *int) string {
+	name := fmt.Sprintf("n%d", *id)
+	*id++
+	label := strings.Join(append([]string{fmt.Sprint(node.GetKey())}, attributes[K](node, extra)...), "\n")
+	style := ""
+	if cn, ok := node.(coloredNode); ok {
+		fill := "black"
+		if cn.IsRed() {
+			fill = "red"
+		}
+		style = fmt.Sprintf(", style=filled, fillcolor=%s, fontcolor=white", fill)
+	}
+	ew.printf("\t%s [label=%q%s];\n", name, label, style)
+	left, right := node.GetLeft(), node.GetRight()
+	if left == sentinel && right == sentinel {
+		return name
+	}
+	for _, child := range [...]orderedset.BBSTNode[K]{left, right} {
+		var childName string
+		if child == sentinel {
+			childName = fmt.Sprintf("n%d", *id)
+			*id++
+			ew.printf("\t%s [shape=point];\n", childName)
+		} else {
+			childName = writeDOTNode[K](ew, child, sentinel, extra, id)
+		}
+		ew.printf("\t%s -> %s;\n", name, childName)
+	}
+	return name
+}
+
+func writeASCII[K any](w io.Writer, root, sentinel orderedset.BBSTNode[K], extra func(orderedset.BBSTNode[K]) string) error {
+	ew := &errWriter{w: w}
+	if root == sentinel {
+		ew.printf("(empty)\n")
+		return ew.err
+	}
+	writeASCIINode[K](ew, root, sentinel, extra, "", "")
+	return ew.err
+}
+
+// writes node on a line starting with branch and its children on following lines starting with prefix
+func writeASCIINode[K any](ew *errWriter, node, sentinel orderedset.BBSTNode[K], extra func(orderedset.BBSTNode[K]) string, branch, prefix string) {
+	fields := []string{fmt.Sprint(node.GetKey())}
+	if cn, ok := node.(coloredNode); ok {
+		if cn.IsRed() {
+			fields = append(fields, "red")
+		} else {
+			fields = append(fields, "black")
+		}
+	}
+	fields = append(fields, attributes[K](node, extra)...)
+	ew.printf("%s%s\n", branch, strings.Join(fields, " "))
+	left, right := node.GetLeft(), node.GetRight()
+	if left == sentinel && right == sentinel {
+		return
+	}
+	if left == sentinel {
+		ew.printf("%s├── -\n", prefix)
+	} else {
+		writeASCIINode[K](ew, left, sentinel, extra, prefix+"├── ", prefix+"│   ")
+	}
+	if right == sentinel {
+		ew.printf("%s└── -\n", prefix)
+	} else {
+		writeASCIINode[K](ew, right, sentinel, extra, prefix+"└── ", prefix+"    ")
+	}
+}
@@ -0,0 +1,112 @@
+package treedebug_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/storybehind/gocontainer/orderedset"
+	"github.com/storybehind/gocontainer/orderedset/treedebug"
+	"github.com/storybehind/gocontainer/orderedset/variants"
+)
+
+func less(k1, k2 int) bool {
+	return k1 < k2
+}
+
+func TestWriteASCII(t *testing.T) {
+	rbTree := orderedset.NewRbTree[int](less)
+	avlTree := orderedset.NewAvlTree[int](less)
+	ost := variants.NewOrderStatisticsTree[int](less)
+	for _, key := range []int{1, 2, 3} {
+		rbTree.ReplaceOrInsert(key)
+		avlTree.ReplaceOrInsert(key)
+		ost.ReplaceOrInsert(key)
+	}
+	avlTree.ReplaceOrInsert(4)
+
+	var sb strings.Builder
+	if err := treedebug.WriteASCII[int](&sb, rbTree.GetRoot(), rbTree.GetSentinel()); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "RbTree", sb.String(), "2 black\n├── 1 red\n└── 3 red\n")
+
+	sb.Reset()
+	if err := treedebug.WriteASCII[int](&sb, avlTree.GetRoot(), avlTree.GetSentinel()); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "AvlTree", sb.String(), "2 h=3\n├── 1 h=1\n└── 3 h=2\n    ├── -\n    └── 4 h=1\n")
+
+	sb.Reset()
+	if err := treedebug.WriteASCIIAugmented[int, int64](&sb, ost.GetRoot(), ost.GetSentinel()); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "OrderStatisticsTree", sb.String(), "2 black aug=3\n├── 1 red aug=1\n└── 3 red aug=1\n")
+
+	sb.Reset()
+	empty := orderedset.NewRbTree[int](less)
+	if err := treedebug.WriteASCII[int](&sb, empty.GetRoot(), empty.GetSentinel()); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "empty RbTree", sb.String(), "(empty)\n")
+}
+
+func TestWriteDOT(t *testing.T) {
+	avlTree := orderedset.NewAvlTree[int](less)
+	for _, key := range []int{1, 2} {
+		avlTree.ReplaceOrInsert(key)
+	}
+	var sb strings.Builder
+	if err := treedebug.WriteDOT[int](&sb, avlTree.GetRoot(), avlTree.GetSentinel()); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "AvlTree", sb.String(), `digraph tree {
+	node [shape=box];
+	n0 [label="1\nh=2"];
+	n1 [shape=point];
+	n0 -> n1;
+	n2 [label="2\nh=1"];
+	n0 -> n2;
+}
+`)
+
+	ost := variants.NewOrderStatisticsTree[int](less)
+	ost.ReplaceOrInsert(1)
+	sb.Reset()
+	if err := treedebug.WriteDOTAugmented[int, int64](&sb, ost.GetRoot(), ost.GetSentinel()); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "OrderStatisticsTree", sb.String(), `digraph tree {
+	node [shape=box];
+	n0 [label="1\naug=1", style=filled, fillcolor=black, fontcolor=white];
+}
+`)
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestWriteError(t *testing.T) {
+	rbTree := orderedset.NewRbTree[int](less)
+	for key := 0; key < 10; key++ {
+		rbTree.ReplaceOrInsert(key)
+	}
+	if err := treedebug.WriteDOT[int](failingWriter{}, rbTree.GetRoot(), rbTree.GetSentinel()); err != errWrite {
+		t.Errorf("WriteDOT error = %v, exp = %v", err, errWrite)
+	}
+	if err := treedebug.WriteASCII[int](failingWriter{}, rbTree.GetRoot(), rbTree.GetSentinel()); err != errWrite {
+		t.Errorf("WriteASCII error = %v, exp = %v", err, errWrite)
+	}
+}
+
+func checkOutput(t *testing.T, name, got, exp string) {
+	t.Helper()
+	if got != exp {
+		t.Errorf("%s output:\n%s\nexp:\n%s", name, got, exp)
+	}
+}
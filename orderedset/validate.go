@@ -0,0 +1,143 @@
+package orderedset
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidTree is wrapped by errors returned from Validate methods when tree violates one of its invariants
+var ErrInvalidTree = errors.New("orderedset: invalid tree")
+
+// Validate checks that the tree satisfies its invariants: keys are in strictly ascending order, parent pointers are consistent,
+// root and sentinel are black, no red node has red child, every path from a node to its leaves has same number of black nodes, and Len() matches number of nodes.
+// It returns nil if tree is valid. Otherwise, an error wrapping ErrInvalidTree describes the first violation found.
+// Takes O(n) time where n is number of keys in the tree.
+func (rbTree *RbTree[K]) Validate() error {
+	if rbTree.sentinel.color != BLACK || rbTree.root.color != BLACK {
+		return fmt.Errorf("%w: root or sentinel is red", ErrInvalidTree)
+	}
+	return validateTree[K](rbTree.root, rbTree.sentinel, rbTree.cmp, rbTree.len, validateRedBlack[K])
+}
+
+// Validate checks that the tree satisfies its invariants: keys are in strictly ascending order, parent pointers are consistent,
+// height of every node is one more than height of its taller child, heights of children differ by at most one, and Len() matches number of nodes.
+// It returns nil if tree is valid. Otherwise, an error wrapping ErrInvalidTree describes the first violation found.
+// Takes O(n) time where n is number of keys in the tree.
+func (avlTree *AvlTree[K]) Validate() error {
+	if avlTree.sentinel.height != 0 {
+		return fmt.Errorf("%w: sentinel has height %d", ErrInvalidTree, avlTree.sentinel.height)
+	}
+	return validateTree[K](avlTree.root, avlTree.sentinel, avlTree.cmp, avlTree.len, func(node BBSTNode[K], leftHeight, rightHeight int64) (int64, error) {
+		height := node.(*avlTreeNode[K]).height
+		if height != 1+max(leftHeight, rightHeight) {
+			return 0, fmt.Errorf("%w: node %v has height %d, children have heights %d and %d", ErrInvalidTree, node.GetKey(), height, leftHeight, rightHeight)
+		}
+		if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+			return 0, fmt.Errorf("%w: node %v is unbalanced, children have heights %d and %d", ErrInvalidTree, node.GetKey(), leftHeight, rightHeight)
+		}
+		return height, nil
+	})
+}
+
+// Validate checks red-black tree invariants as RbTree.Validate does.
+// Additionally, it checks that augmented value of every node equals to the value recomputed by updateAugmentValue from its children, compared by reflect.DeepEqual.
+// It returns nil if tree is valid. Otherwise, an error wrapping ErrInvalidTree describes the first violation found.
+// Takes O(t * n) time where n is number of keys in the tree and t is updateAugmentValue time.
+func (rbTreeAugmented *RbTreeAugmented[K, A]) Validate() error {
+	if rbTreeAugmented.sentinel.color != BLACK || rbTreeAugmented.root.color != BLACK {
+		return fmt.Errorf("%w: root or sentinel is red", ErrInvalidTree)
+	}
+	return validateTree[K](rbTreeAugmented.root, rbTreeAugmented.sentinel, rbTreeAugmented.cmp, rbTreeAugmented.len, func(node BBSTNode[K], leftBlackHeight, rightBlackHeight int64) (int64, error) {
+		augmentedNode := node.(*rbTreeNodeAugmented[K, A])
+		if expected := rbTreeAugmented.updateAugmentValue(augmentedNode, rbTreeAugmented.sentinel); !reflect.DeepEqual(augmentedNode.augmentedValue, expected) {
+			return 0, fmt.Errorf("%w: node %v has augmented value %v, expected %v", ErrInvalidTree, node.GetKey(), augmentedNode.augmentedValue, expected)
+		}
+		return validateRedBlack[K](node, leftBlackHeight, rightBlackHeight)
+	})
+}
+
+// checks red-black properties of node whose subtrees have the given black heights, returning black height of node
+func validateRedBlack[K any](node BBSTNode[K], leftBlackHeight, rightBlackHeight int64) (int64, error) {
+	if leftBlackHeight != rightBlackHeight {
+		return 0, fmt.Errorf("%w: children of node %v have black heights %d and %d", ErrInvalidTree, node.GetKey(), leftBlackHeight, rightBlackHeight)
+	}
+	type coloredNode interface{ IsRed() bool }
+	if !node.(coloredNode).IsRed() {
+		return leftBlackHeight + 1, nil
+	}
+	for _, child := range [...]BBSTNode[K]{node.GetLeft(), node.GetRight()} {
+		if child.(coloredNode).IsRed() {
+			return 0, fmt.Errorf("%w: red node %v has red child %v", ErrInvalidTree, node.GetKey(), child.GetKey())
+		}
+	}
+	return leftBlackHeight, nil
+}
+
+// walks tree rooted at root in order checking ordering of keys, parent pointers and number of nodes.
+// checkNode is called on every node after its subtrees with values it returned for them (0 for sentinel), and returns value of node.
+func validateTree[K any](root, sentinel BBSTNode[K], cmp compare[K], len int64, checkNode func(node BBSTNode[K], left, right int64) (int64, error)) error {
+	if root != sentinel && !isNilNode[K](root) && root.GetParent() != sentinel {
+		return fmt.Errorf("%w: parent of root %v is not sentinel", ErrInvalidTree, root.GetKey())
+	}
+	v := &treeValidator[K]{sentinel: sentinel, cmp: cmp, len: len, checkNode: checkNode}
+	if _, err := v.walk(root); err != nil {
+		return err
+	}
+	if v.count != len {
+		return fmt.Errorf("%w: Len() is %d but tree has %d nodes", ErrInvalidTree, len, v.count)
+	}
+	return nil
+}
+
+type treeValidator[K any] struct {
+	sentinel  BBSTNode[K]
+	cmp       compare[K]
+	len       int64
+	checkNode func(node BBSTNode[K], left, right int64) (int64, error)
+	// last visited node in order
+	prev  BBSTNode[K]
+	count int64
+}
+
+func (v *treeValidator[K]) walk(node BBSTNode[K]) (int64, error) {
+	if node == v.sentinel {
+		return 0, nil
+	}
+	if isNilNode[K](node) {
+		return 0, fmt.Errorf("%w: nil child", ErrInvalidTree)
+	}
+	// stops cycles and runaway paths from walking forever
+	if v.count >= v.len {
+		return 0, fmt.Errorf("%w: tree has more nodes than Len() %d", ErrInvalidTree, v.len)
+	}
+	left, right := node.GetLeft(), node.GetRight()
+	for _, child := range [...]BBSTNode[K]{left, right} {
+		if child != v.sentinel && !isNilNode[K](child) && child.GetParent() != node {
+			return 0, fmt.Errorf("%w: parent of node %v is not node %v", ErrInvalidTree, child.GetKey(), node.GetKey())
+		}
+	}
+	leftValue, err := v.walk(left)
+	if err != nil {
+		return 0, err
+	}
+	if v.prev != nil && v.cmp(v.prev.GetKey(), node.GetKey()) >= 0 {
+		return 0, fmt.Errorf("%w: key %v does not precede key %v", ErrInvalidTree, v.prev.GetKey(), node.GetKey())
+	}
+	v.prev = node
+	v.count++
+	rightValue, err := v.walk(right)
+	if err != nil {
+		return 0, err
+	}
+	return v.checkNode(node, leftValue, rightValue)
+}
+
+// reports whether node is nil. Nodes of trees are returned as typed pointers, so a nil pointer held by BBSTNode is not equal to nil
+func isNilNode[K any](node BBSTNode[K]) bool {
+	if node == nil {
+		return true
+	}
+	value := reflect.ValueOf(node)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
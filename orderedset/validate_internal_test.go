@@ -0,0 +1,96 @@
+package orderedset
+
+import (
+	"errors"
+	"testing"
+)
+
+// corrupts nodes directly, which is not possible through exported API
+func TestValidateCorrupted(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	newRbTree := func() *RbTree[int] {
+		rbTree := NewRbTree[int](less)
+		for key := 0; key < 16; key++ {
+			rbTree.ReplaceOrInsert(key)
+		}
+		return rbTree
+	}
+	newAvlTree := func() *AvlTree[int] {
+		avlTree := NewAvlTree[int](less)
+		for key := 0; key < 16; key++ {
+			avlTree.ReplaceOrInsert(key)
+		}
+		return avlTree
+	}
+	tests := map[string]func() error{
+		"red root": func() error {
+			rbTree := newRbTree()
+			rbTree.root.color = RED
+			return rbTree.Validate()
+		},
+		"black height": func() error {
+			rbTree := newRbTree()
+			leaf := getMinNode[int](rbTree.root, rbTree.sentinel).(*rbTreeNode[int])
+			leaf.color = 1 - leaf.color
+			return rbTree.Validate()
+		},
+		"red red": func() error {
+			rbTree := NewRbTree[int](less)
+			for _, key := range []int{2, 4, 6} {
+				rbTree.ReplaceOrInsert(key)
+			}
+			// red child under red node 2 keeps black heights
+			redNode := rbTree.root.left
+			redNode.left = &rbTreeNode[int]{left: rbTree.sentinel, right: rbTree.sentinel, parent: redNode, key: 1, color: RED}
+			rbTree.len++
+			return rbTree.Validate()
+		},
+		"parent": func() error {
+			rbTree := newRbTree()
+			rbTree.root.left.left.parent = rbTree.root
+			return rbTree.Validate()
+		},
+		"nil child": func() error {
+			rbTree := newRbTree()
+			rbTree.root.left.left = nil
+			return rbTree.Validate()
+		},
+		"avl nil child": func() error {
+			avlTree := newAvlTree()
+			avlTree.root.right.right = nil
+			return avlTree.Validate()
+		},
+		"len": func() error {
+			rbTree := newRbTree()
+			rbTree.len++
+			return rbTree.Validate()
+		},
+		"cycle": func() error {
+			rbTree := newRbTree()
+			leaf := getMaxNode[int](rbTree.root, rbTree.sentinel).(*rbTreeNode[int])
+			leaf.right = rbTree.root
+			return rbTree.Validate()
+		},
+		"height": func() error {
+			avlTree := newAvlTree()
+			avlTree.root.height++
+			return avlTree.Validate()
+		},
+		"balance": func() error {
+			avlTree := newAvlTree()
+			avlTree.root.left.left = avlTree.sentinel
+			avlTree.root.left.right = avlTree.sentinel
+			avlTree.root.left.height = 1
+			avlTree.len = 0
+			for node := getMinNode[int](avlTree.root, avlTree.sentinel); node != avlTree.sentinel; node = Next[int](node, avlTree.sentinel) {
+				avlTree.len++
+			}
+			return avlTree.Validate()
+		},
+	}
+	for name, test := range tests {
+		if err := test(); !errors.Is(err, ErrInvalidTree) {
+			t.Errorf("%s: Validate() err = %v, exp = %v", name, err, ErrInvalidTree)
+		}
+	}
+}
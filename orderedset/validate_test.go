@@ -0,0 +1,106 @@
+package orderedset_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	orderedset "github.com/storybehind/gocontainer/orderedset"
+)
+
+type validatedOrderedSet interface {
+	orderedset.OrderedSetI[int]
+	ReplaceAllSorted(keys []int) error
+	Validate() error
+}
+
+func countAugmentValue(node, sentinel orderedset.BBSTNodeAugmented[int, int]) int {
+	count := 1
+	if node.GetLeftAugmented() != sentinel {
+		count += node.GetLeftAugmented().GetAugmentedValue()
+	}
+	if node.GetRightAugmented() != sentinel {
+		count += node.GetRightAugmented().GetAugmentedValue()
+	}
+	return count
+}
+
+func TestValidate(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	trees := map[string]validatedOrderedSet{
+		"RbTree":          orderedset.NewRbTree[int](less),
+		"AvlTree":         orderedset.NewAvlTree[int](less),
+		"RbTreeAugmented": orderedset.NewRbTreeAugmented[int, int](less, countAugmentValue),
+	}
+	for name, tree := range trees {
+		r := rand.New(rand.NewSource(1))
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("%s: Validate() of empty tree err = %v", name, err)
+		}
+		for i := 0; i < 2000; i++ {
+			key := r.Intn(500)
+			switch r.Intn(5) {
+			case 0, 1:
+				tree.ReplaceOrInsert(key)
+			case 2:
+				tree.Delete(key)
+			case 3:
+				tree.DeleteMin()
+			case 4:
+				iterator := tree.Rbegin()
+				if _, ok := iterator.Key(); ok {
+					iterator.Remove()
+				}
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("%s: Validate() after %d operations err = %v", name, i+1, err)
+			}
+		}
+		for n := 0; n < 40; n++ {
+			keys := make([]int, n)
+			for i := range keys {
+				keys[i] = i
+			}
+			if err := tree.ReplaceAllSorted(keys); err != nil {
+				t.Fatalf("%s: ReplaceAllSorted() err = %v", name, err)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("%s: Validate() after ReplaceAllSorted of %d keys err = %v", name, n, err)
+			}
+		}
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+	// reversing order of keys after insertion breaks ordering invariant
+	reversed := false
+	less := func(k1, k2 int) bool { return (k1 < k2) != reversed }
+	rbTree := orderedset.NewRbTree[int](less)
+	avlTree := orderedset.NewAvlTree[int](less)
+	for key := 0; key < 10; key++ {
+		rbTree.ReplaceOrInsert(key)
+		avlTree.ReplaceOrInsert(key)
+	}
+	reversed = true
+	for name, tree := range map[string]validatedOrderedSet{"RbTree": rbTree, "AvlTree": avlTree} {
+		if err := tree.Validate(); !errors.Is(err, orderedset.ErrInvalidTree) {
+			t.Errorf("%s: Validate() of unordered tree err = %v, exp = %v", name, err, orderedset.ErrInvalidTree)
+		}
+	}
+
+	// changing updateAugmentValue breaks augmented values
+	offset := 0
+	augmented := orderedset.NewRbTreeAugmented[int, int](func(k1, k2 int) bool { return k1 < k2 }, func(node, sentinel orderedset.BBSTNodeAugmented[int, int]) int {
+		return countAugmentValue(node, sentinel) + offset
+	})
+	for key := 0; key < 10; key++ {
+		augmented.ReplaceOrInsert(key)
+	}
+	if err := augmented.Validate(); err != nil {
+		t.Fatalf("Validate() err = %v", err)
+	}
+	offset = 1
+	if err := augmented.Validate(); !errors.Is(err, orderedset.ErrInvalidTree) {
+		t.Errorf("Validate() of stale augmented values err = %v, exp = %v", err, orderedset.ErrInvalidTree)
+	}
+}
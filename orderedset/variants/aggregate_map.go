@@ -0,0 +1,190 @@
+package variants
+
+import (
+	"github.com/storybehind/gocontainer/orderedmap"
+	"github.com/storybehind/gocontainer/orderedset"
+)
+
+// Maintains key value pairs where all keys are unique and augments every subtree with the aggregate of its values.
+// Supports insertion, deletion, search, range aggregation and prefix search operations in O(log n) time where n is number of keys in the map
+type AggregateMap[K, V, A any] struct {
+	tree      *orderedset.RbTreeAugmented[orderedmap.KeyValuePair[K, V], A]
+	cmp       func(K, K) int
+	identity  A
+	aggregate func(value V) A
+	combine   func(a1, a2 A) A
+}
+
+// Returns instance of AggregateMap.
+// Less method determines the order of key.
+// k1 precedes k2 if and only if Less(k1, k2) return true.
+// k1 equals k2 if and only if !Less(k1, k2) && !Less(k2, k1) holds true.
+//
+// aggregate maps a value to its aggregate and combine merges aggregates of adjacent key ranges (left range first).
+// combine must be associative and identity must satisfy combine(identity, a) == combine(a, identity) == a.
+// Ex: for range sum, identity = 0, aggregate(v) = v and combine(a1, a2) = a1 + a2
+func NewAggregateMap[K, V, A any](less func(k1, k2 K) bool, identity A, aggregate func(value V) A, combine func(a1, a2 A) A) *AggregateMap[K, V, A] {
+	am := &AggregateMap[K, V, A]{
+		cmp: func(k1, k2 K) int {
+			if less(k1, k2) {
+				return -1
+			}
+			if less(k2, k1) {
+				return 1
+			}
+			return 0
+		},
+		identity:  identity,
+		aggregate: aggregate,
+		combine:   combine,
+	}
+	am.tree = orderedset.NewRbTreeAugmented[orderedmap.KeyValuePair[K, V], A](func(kv1, kv2 orderedmap.KeyValuePair[K, V]) bool {
+		return less(kv1.GetKey(), kv2.GetKey())
+	}, func(node, sentinel orderedset.BBSTNodeAugmented[orderedmap.KeyValuePair[K, V], A]) A {
+		left := am.getSubtreeAggregate(node.GetLeftAugmented())
+		right := am.getSubtreeAggregate(node.GetRightAugmented())
+		return am.combine(am.combine(left, am.aggregate(node.GetKey().GetValue())), right)
+	})
+	return am
+}
+
+func (am *AggregateMap[K, V, A]) getSubtreeAggregate(node orderedset.BBSTNodeAugmented[orderedmap.KeyValuePair[K, V], A]) A {
+	if node == am.tree.GetSentinel() {
+		return am.identity
+	}
+	return node.GetAugmentedValue()
+}
+
+func (am *AggregateMap[K, V, A]) getNodeAggregate(node orderedset.BBSTNodeAugmented[orderedmap.KeyValuePair[K, V], A]) A {
+	return am.aggregate(node.GetKey().GetValue())
+}
+
+// Get looks for the key in the map, returning its KeyValuePair. It returns (zeroValue, false) if unable to find that key
+func (am *AggregateMap[K, V, A]) Get(key K) (_ orderedmap.KeyValuePair[K, V], _ bool) {
+	var zero V
+	return am.tree.Get(orderedmap.NewKeyValuePair(key, zero))
+}
+
+// Max returns KeyValuePair with largest key, or (zeroValue, false) if the map is empty
+func (am *AggregateMap[K, V, A]) Max() (_ orderedmap.KeyValuePair[K, V], _ bool) {
+	return am.tree.Max()
+}
+
+// Min returns KeyValuePair with smallest key, or (zeroValue, false) if the map is empty
+func (am *AggregateMap[K, V, A]) Min() (_ orderedmap.KeyValuePair[K, V], _ bool) {
+	return am.tree.Min()
+}
+
+// Len returns the number of keys currently in the map.
+func (am *AggregateMap[K, V, A]) Len() int64 {
+	return am.tree.Len()
+}
+
+// ReplaceOrInsert adds the given key and value to the map.
+// If a key in the map already equals the given one, it is removed from the map and returns its KeyValuePair, and the second return value is true.
+// Otherwise, (zeroValue, false)
+func (am *AggregateMap[K, V, A]) ReplaceOrInsert(key K, value V) (_ orderedmap.KeyValuePair[K, V], _ bool) {
+	return am.tree.ReplaceOrInsert(orderedmap.NewKeyValuePair(key, value))
+}
+
+// Delete the key in the map and return its KeyValuePair, and the second return value is true.
+// If key is not found in the map, returns (zeroValue, false)
+func (am *AggregateMap[K, V, A]) Delete(key K) (_ orderedmap.KeyValuePair[K, V], _ bool) {
+	var zero V
+	return am.tree.Delete(orderedmap.NewKeyValuePair(key, zero))
+}
+
+// Returns an iterator pointing to least key in the map.
+// Used to iterate KeyValuePairs in the ascending order of keys.
+func (am *AggregateMap[K, V, A]) Begin() orderedset.OrderedSetForwardIterator[orderedmap.KeyValuePair[K, V]] {
+	return am.tree.Begin()
+}
+
+// Returns an reverse iterator pointing to greatest key in the map.
+// Used to iterate KeyValuePairs in the descending order of keys.
+func (am *AggregateMap[K, V, A]) Rbegin() orderedset.OrderedSetReverseIterator[orderedmap.KeyValuePair[K, V]] {
+	return am.tree.Rbegin()
+}
+
+// Returns aggregate of all values in the map, or identity if the map is empty. Takes O(1) time.
+func (am *AggregateMap[K, V, A]) Aggregate() A {
+	return am.getSubtreeAggregate(am.tree.GetRoot())
+}
+
+// Returns aggregate of values whose keys are lower than or equal to key, or identity if there is no such key.
+// Takes O(log n) time
+func (am *AggregateMap[K, V, A]) PrefixAggregate(key K) A {
+	return am.prefixAggregate(am.tree.GetRoot(), key)
+}
+
+// Returns aggregate of values whose keys lie in the range [lo, hi], or identity if there is no such key.
+// Takes O(log n) time
+func (am *AggregateMap[K, V, A]) RangeAggregate(lo, hi K) A {
+	node := am.tree.GetRoot()
+	for node != am.tree.GetSentinel() {
+		if am.cmp(node.GetKey().GetKey(), lo) < 0 {
+			node = node.GetRightAugmented()
+			continue
+		}
+		if am.cmp(node.GetKey().GetKey(), hi) > 0 {
+			node = node.GetLeftAugmented()
+			continue
+		}
+		// node splits the range; left subtree holds keys >= lo and right subtree holds keys <= hi
+		left := am.suffixAggregate(node.GetLeftAugmented(), lo)
+		right := am.prefixAggregate(node.GetRightAugmented(), hi)
+		return am.combine(am.combine(left, am.getNodeAggregate(node)), right)
+	}
+	return am.identity
+}
+
+// SearchPrefixAggregate looks for smallest key whose prefix aggregate (aggregate of values with keys lower than or equal to it) satisfies pred, returning its KeyValuePair.
+// pred must be monotone, i.e once it holds true for a prefix aggregate, it holds true for every longer prefix aggregate.
+// Ex: with range sum over non negative values, pred = func(sum int) bool { return sum >= x } finds first key where prefix sum reaches x.
+// It returns (zeroValue, false) if no prefix aggregate satisfies pred. Takes O(log n) time
+func (am *AggregateMap[K, V, A]) SearchPrefixAggregate(pred func(a A) bool) (_ orderedmap.KeyValuePair[K, V], _ bool) {
+	acc := am.identity
+	node := am.tree.GetRoot()
+	for node != am.tree.GetSentinel() {
+		withLeft := am.combine(acc, am.getSubtreeAggregate(node.GetLeftAugmented()))
+		if node.GetLeftAugmented() != am.tree.GetSentinel() && pred(withLeft) {
+			node = node.GetLeftAugmented()
+			continue
+		}
+		withNode := am.combine(withLeft, am.getNodeAggregate(node))
+		if pred(withNode) {
+			return node.GetKey(), true
+		}
+		acc = withNode
+		node = node.GetRightAugmented()
+	}
+	return
+}
+
+// aggregate of values in the subtree whose keys are lower than or equal to hi
+func (am *AggregateMap[K, V, A]) prefixAggregate(node orderedset.BBSTNodeAugmented[orderedmap.KeyValuePair[K, V], A], hi K) A {
+	acc := am.identity
+	for node != am.tree.GetSentinel() {
+		if am.cmp(node.GetKey().GetKey(), hi) > 0 {
+			node = node.GetLeftAugmented()
+			continue
+		}
+		acc = am.combine(acc, am.combine(am.getSubtreeAggregate(node.GetLeftAugmented()), am.getNodeAggregate(node)))
+		node = node.GetRightAugmented()
+	}
+	return acc
+}
+
+// aggregate of values in the subtree whose keys are greater than or equal to lo
+func (am *AggregateMap[K, V, A]) suffixAggregate(node orderedset.BBSTNodeAugmented[orderedmap.KeyValuePair[K, V], A], lo K) A {
+	acc := am.identity
+	for node != am.tree.GetSentinel() {
+		if am.cmp(node.GetKey().GetKey(), lo) < 0 {
+			node = node.GetRightAugmented()
+			continue
+		}
+		acc = am.combine(am.combine(am.getNodeAggregate(node), am.getSubtreeAggregate(node.GetRightAugmented())), acc)
+		node = node.GetLeftAugmented()
+	}
+	return acc
+}
@@ -0,0 +1,93 @@
+package variants_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/storybehind/gocontainer/orderedset/variants"
+)
+
+func TestAggregateMapSum(t *testing.T) {
+	am := variants.NewAggregateMap[int, int, int](func(k1, k2 int) bool {
+		return k1 < k2
+	}, 0, func(v int) int { return v }, func(a1, a2 int) int { return a1 + a2 })
+	assertAggregate := func(name string, found, exp int) {
+		if found != exp {
+			t.Errorf("%s err; found = %d, exp = %d", name, found, exp)
+		}
+	}
+
+	assertAggregate("Aggregate", am.Aggregate(), 0)
+	assertAggregate("RangeAggregate", am.RangeAggregate(0, 10), 0)
+	if _, has := am.SearchPrefixAggregate(func(sum int) bool { return sum >= 0 }); has {
+		t.Errorf("SearchPrefixAggregate err; expected no key in empty map")
+	}
+
+	// map contains {1:10, 3:30, 5:50, 7:70, 9:90}
+	for key := 1; key <= 9; key += 2 {
+		am.ReplaceOrInsert(key, 10*key)
+	}
+	assertAggregate("Aggregate", am.Aggregate(), 250)
+	assertAggregate("PrefixAggregate", am.PrefixAggregate(0), 0)
+	assertAggregate("PrefixAggregate", am.PrefixAggregate(5), 90)
+	assertAggregate("PrefixAggregate", am.PrefixAggregate(6), 90)
+	assertAggregate("PrefixAggregate", am.PrefixAggregate(100), 250)
+	assertAggregate("RangeAggregate", am.RangeAggregate(3, 7), 150)
+	assertAggregate("RangeAggregate", am.RangeAggregate(2, 8), 150)
+	assertAggregate("RangeAggregate", am.RangeAggregate(8, 2), 0)
+	assertAggregate("RangeAggregate", am.RangeAggregate(10, 20), 0)
+
+	kvpair, has := am.SearchPrefixAggregate(func(sum int) bool { return sum >= 40 })
+	if !has || kvpair.GetKey() != 3 {
+		t.Errorf("SearchPrefixAggregate err; found key = %d, has = %v, exp key = 3", kvpair.GetKey(), has)
+	}
+	if _, has = am.SearchPrefixAggregate(func(sum int) bool { return sum > 250 }); has {
+		t.Errorf("SearchPrefixAggregate err; expected no key with prefix sum > 250")
+	}
+
+	// replace value of key 5 and delete key 3. map contains {1:10, 5:5, 7:70, 9:90}
+	am.ReplaceOrInsert(5, 5)
+	am.Delete(3)
+	assertAggregate("Aggregate", am.Aggregate(), 175)
+	assertAggregate("RangeAggregate", am.RangeAggregate(1, 5), 15)
+}
+
+func TestAggregateMapRandom(t *testing.T) {
+	// concatenation is associative but not commutative, so this also checks order of aggregation
+	am := variants.NewAggregateMap[int, string, string](func(k1, k2 int) bool {
+		return k1 < k2
+	}, "", func(v string) string { return v }, func(a1, a2 string) string { return a1 + a2 })
+	model := make(map[int]string)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		key := r.Intn(100)
+		if r.Intn(3) == 0 {
+			am.Delete(key)
+			delete(model, key)
+		} else {
+			value := string(rune('a' + r.Intn(26)))
+			am.ReplaceOrInsert(key, value)
+			model[key] = value
+		}
+		keys := make([]int, 0, len(model))
+		for key := range model {
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+
+		lo, hi := r.Intn(100), r.Intn(100)
+		exp := ""
+		for _, key := range keys {
+			if lo <= key && key <= hi {
+				exp += model[key]
+			}
+		}
+		if found := am.RangeAggregate(lo, hi); found != exp {
+			t.Fatalf("RangeAggregate(%d, %d) = %q, exp = %q", lo, hi, found, exp)
+		}
+		if am.Len() != int64(len(keys)) {
+			t.Fatalf("am.Len() = %d, exp = %d", am.Len(), len(keys))
+		}
+	}
+}
@@ -16,9 +16,7 @@ type OrderStatisticsTree[K any] struct {
 // k1 equals k2 if and only if !Less(k1, k2) && !Less(k2, k1) holds true.
 func NewOrderStatisticsTree[K any](less func(k1, k2 K) bool) *OrderStatisticsTree[K] {
 	return &OrderStatisticsTree[K]{
-		RbTreeAugmented: orderedset.NewRbTreeAugmented[K, int64](less, func(node, sentinel orderedset.BBSTNodeAugmented[K, int64]) int64 {
-			return 1 + getSubtreeSize[K](node.GetLeftAugmented(), sentinel) + getSubtreeSize[K](node.GetRightAugmented(), sentinel)
-		}),
+		RbTreeAugmented: orderedset.NewRbTreeAugmented[K, int64](less, updateSubtreeSize[K]),
 		less: less,
 		cmp: func(k1, k2 K) int {
 			if less(k1, k2) {
@@ -32,6 +30,21 @@ func NewOrderStatisticsTree[K any](less func(k1, k2 K) bool) *OrderStatisticsTre
 	}
 }
 
+// Returns instance of OrderStatisticsTree ordered by three-way comparison function as in cmp.Compare.
+func NewOrderStatisticsTreeFunc[K any](cmp func(k1, k2 K) int) *OrderStatisticsTree[K] {
+	return &OrderStatisticsTree[K]{
+		RbTreeAugmented: orderedset.NewRbTreeAugmentedFunc[K, int64](cmp, updateSubtreeSize[K]),
+		less: func(k1, k2 K) bool {
+			return cmp(k1, k2) < 0
+		},
+		cmp: cmp,
+	}
+}
+
+func updateSubtreeSize[K any](node, sentinel orderedset.BBSTNodeAugmented[K, int64]) int64 {
+	return 1 + getSubtreeSize[K](node.GetLeftAugmented(), sentinel) + getSubtreeSize[K](node.GetRightAugmented(), sentinel)
+}
+
 func getSubtreeSize[K any](node, sentinel orderedset.BBSTNodeAugmented[K, int64]) int64 {
 	if node == sentinel {
 		return 0
@@ -47,13 +60,13 @@ func (ost *OrderStatisticsTree[K]) Rank(key K) int64 {
 	node := ost.GetRoot()
 	for node != ost.GetSentinel() {
 		cmp := ost.cmp(key, node.GetKey())
-		switch cmp {
-		case 0:
+		switch {
+		case cmp == 0:
 			rank += getSubtreeSize(node.GetLeftAugmented(), ost.GetSentinel())
 			return rank
-		case -1:
+		case cmp < 0:
 			node = node.GetLeftAugmented()
-		case 1:
+		default:
 			rank += 1 + getSubtreeSize(node.GetLeftAugmented(), ost.GetSentinel())
 			node = node.GetRightAugmented()
 		}
@@ -1,6 +1,8 @@
 package variants_test
 
 import (
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/storybehind/gocontainer/orderedset/variants"
@@ -63,3 +65,85 @@ func TestOrderStatisticsTree(t *testing.T) {
 	assertSelect(2, 5, true)
 	assertSelect(3, 0, false)
 }
+
+func TestOrderStatisticsTreeRandom(t *testing.T) {
+	ost := variants.NewOrderStatisticsTree[int](func(k1, k2 int) bool {
+		return k1 < k2
+	})
+	model := make(map[int]bool)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		key := r.Intn(100)
+		if r.Intn(3) == 0 {
+			ost.Delete(key)
+			delete(model, key)
+		} else {
+			ost.ReplaceOrInsert(key)
+			model[key] = true
+		}
+		keys := make([]int, 0, len(model))
+		for key := range model {
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+		for rank, key := range keys {
+			if found := ost.Rank(key); found != int64(rank) {
+				t.Fatalf("Rank(%d) = %d, exprank = %d", key, found, rank)
+			}
+		}
+	}
+}
+
+func TestOrderStatisticsTreeBinary(t *testing.T) {
+	less := func(k1, k2 int) bool { return k1 < k2 }
+	ost := variants.NewOrderStatisticsTree[int](less)
+	for key := 0; key < 100; key++ {
+		ost.ReplaceOrInsert(key * 2)
+	}
+	data, err := ost.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() err = %v", err)
+	}
+	decoded := variants.NewOrderStatisticsTree[int](less)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() err = %v", err)
+	}
+	// subtree sizes are recomputed by bulk build
+	for rank := int64(0); rank < 100; rank++ {
+		if key, ok := decoded.Select(rank); !ok || key != int(rank*2) {
+			t.Errorf("Select(%d) = (%d, %v), exp = (%d, true)", rank, key, ok, rank*2)
+		}
+		if r := decoded.Rank(int(rank * 2)); r != rank {
+			t.Errorf("Rank(%d) = %d, exp = %d", rank*2, r, rank)
+		}
+	}
+	decoded.Delete(0)
+	decoded.ReplaceOrInsert(1)
+	if r := decoded.Rank(198); r != 99 {
+		t.Errorf("Rank(198) = %d, exp = 99", r)
+	}
+}
+
+func TestOrderStatisticsTreeOrdered(t *testing.T) {
+	// keys in descending order by a comparator returning magnitudes other than 1
+	descending := variants.NewOrderStatisticsTreeFunc[int](func(k1, k2 int) int { return (k2 - k1) * 10 })
+	ascending := variants.NewOrderedOrderStatisticsTree[int]()
+	for key := 1; key <= 5; key++ {
+		descending.ReplaceOrInsert(key)
+		ascending.ReplaceOrInsert(key)
+	}
+	for key := 1; key <= 5; key++ {
+		if rank := ascending.Rank(key); rank != int64(key-1) {
+			t.Errorf("ascending Rank(%d) = %d, exp = %d", key, rank, key-1)
+		}
+		if rank := descending.Rank(key); rank != int64(5-key) {
+			t.Errorf("descending Rank(%d) = %d, exp = %d", key, rank, 5-key)
+		}
+	}
+	if key, ok := descending.Select(0); !ok || key != 5 {
+		t.Errorf("descending Select(0) = (%d, %v), exp = (5, true)", key, ok)
+	}
+	if rank := descending.Rank(6); rank != -1 {
+		t.Errorf("Rank(6) of absent key = %d, exp = -1", rank)
+	}
+}
@@ -0,0 +1,8 @@
+package variants
+
+import "cmp"
+
+// Returns instance of OrderStatisticsTree ordering keys ascending by cmp.Compare.
+func NewOrderedOrderStatisticsTree[K cmp.Ordered]() *OrderStatisticsTree[K] {
+	return NewOrderStatisticsTreeFunc[K](cmp.Compare[K])
+}
@@ -0,0 +1,71 @@
+package priorityqueue
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/storybehind/gocontainer/codec"
+)
+
+// Sets codec of values used by MarshalBinary and UnmarshalBinary. By default, codec.Default[V]() is used.
+func (bh *BinaryHeap[V]) SetCodec(c codec.Codec[V]) {
+	bh.codec = c
+}
+
+// Encodes the binary heap as versioned header followed by its values. Implements encoding.BinaryMarshaler.
+// Takes O(n) time, or O(n log n) time for stable heap whose values are encoded in insertion order, where n is number of values in the queue.
+func (bh *BinaryHeap[V]) MarshalBinary() ([]byte, error) {
+	nodes := append([]*BinaryHeapNode[V]{}, bh.nodes[1:bh.length+1]...)
+	if bh.stable {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].seq < nodes[j].seq
+		})
+	}
+	values := make([]V, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.value
+	}
+	return codec.Marshal[V](codec.PriorityQueueKind, values, bh.valueCodec())
+}
+
+// Decodes data written by MarshalBinary, replacing contents of the binary heap in O(n) time after decoding. Nodes of previous values are removed.
+// Implements encoding.BinaryUnmarshaler. Binary heap must be created by a constructor since priority function cannot be decoded.
+func (bh *BinaryHeap[V]) UnmarshalBinary(data []byte) error {
+	if bh.priorityFunc == nil {
+		return errors.New("priorityqueue: UnmarshalBinary on binary heap not created by constructor")
+	}
+	values, err := codec.Unmarshal[V](data, codec.PriorityQueueKind, bh.valueCodec())
+	if err != nil {
+		return err
+	}
+	bh.replaceAll(values)
+	return nil
+}
+
+// Same as MarshalBinary. Implements gob.GobEncoder.
+func (bh *BinaryHeap[V]) GobEncode() ([]byte, error) {
+	return bh.MarshalBinary()
+}
+
+// Same as UnmarshalBinary. Implements gob.GobDecoder.
+func (bh *BinaryHeap[V]) GobDecode(data []byte) error {
+	return bh.UnmarshalBinary(data)
+}
+
+func (bh *BinaryHeap[V]) valueCodec() codec.Codec[V] {
+	if bh.codec == nil {
+		return codec.Default[V]()
+	}
+	return bh.codec
+}
+
+// removes every node and pushes values in O(n) time where n = len(values)
+func (bh *BinaryHeap[V]) replaceAll(values []V) {
+	for _, node := range bh.nodes[1 : bh.length+1] {
+		node.binaryHeap = nil
+		node.index = 0
+	}
+	bh.nodes = make([]*BinaryHeapNode[V], 1, len(values)+1)
+	bh.length = 0
+	bh.PushAll(values)
+}
@@ -1,10 +1,17 @@
 package priorityqueue
 
+import (
+	"math/bits"
+
+	"github.com/storybehind/gocontainer/codec"
+)
+
 // Binary heap node 
 type BinaryHeapNode[V any] struct {
 	index      int64
 	binaryHeap *BinaryHeap[V]
 	value      V
+	seq        int64
 }
 
 // Returns node's value
@@ -12,42 +19,99 @@ func (bhn *BinaryHeapNode[V]) GetValue() V {
 	return bhn.value
 }
 
-// Implements priorityqueue with Push, Pop, Top, Update and Remove operations
+// Implements priorityqueue with Push, Pop, Top, Update and Remove operations.
+// Every node has at most arity children. arity is 2 unless the heap is created as d-ary heap.
+// In stable heap, values with equal priority are served in insertion order.
 type BinaryHeap[V any] struct {
 	nodes        []*BinaryHeapNode[V]
 	priorityFunc func(v1, v2 V) bool
 	length       int64
+	arity        int64
+	stable       bool
+	// insertion sequence number of next pushed value
+	nextSeq      int64
+	// codec of values used by binary encoding. nil means codec.Default
+	codec        codec.Codec[V]
 }
 
 // Returns instance of BinaryHeap. 
 // v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
 func NewBinaryHeap[V any](priorityFunc func(v1, v2 V) bool) *BinaryHeap[V] {
+	return NewDaryHeap[V](priorityFunc, 2)
+}
+
+// Returns instance of BinaryHeap. 
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// Takes O(n) time where n = len(initValues)
+func InitBinaryHeap[V any](priorityFunc func(v1, v2 V) bool, initValues []V) *BinaryHeap[V] {
+	return InitDaryHeap[V](priorityFunc, 2, initValues)
+}
+
+// Returns instance of BinaryHeap where every node has at most arity children (d-ary heap).
+// Higher arity makes Push and Update (towards higher priority) cheaper at the cost of Pop and Remove.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// panics if arity is less than 2
+func NewDaryHeap[V any](priorityFunc func(v1, v2 V) bool, arity int) *BinaryHeap[V] {
+	return newHeap[V](priorityFunc, arity, false)
+}
+
+// Returns instance of BinaryHeap where every node has at most arity children (d-ary heap).
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// Takes O(n) time where n = len(initValues). panics if arity is less than 2
+func InitDaryHeap[V any](priorityFunc func(v1, v2 V) bool, arity int, initValues []V) *BinaryHeap[V] {
+	return initHeap[V](priorityFunc, arity, false, initValues)
+}
+
+// Returns instance of stable BinaryHeap. Values with equal priority, i.e both priorityFunc(v1, v2) and priorityFunc(v2, v1) return false,
+// are served in the order they are pushed. Update retains value's original insertion order.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+func NewStableBinaryHeap[V any](priorityFunc func(v1, v2 V) bool) *BinaryHeap[V] {
+	return newHeap[V](priorityFunc, 2, true)
+}
+
+// Returns instance of stable BinaryHeap. Values with equal priority are served in the order they are pushed, where initValues are pushed in slice order.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// Takes O(n) time where n = len(initValues)
+func InitStableBinaryHeap[V any](priorityFunc func(v1, v2 V) bool, initValues []V) *BinaryHeap[V] {
+	return initHeap[V](priorityFunc, 2, true, initValues)
+}
+
+func newHeap[V any](priorityFunc func(v1, v2 V) bool, arity int, stable bool) *BinaryHeap[V] {
+	if arity < 2 {
+		panic("arity must be at least 2")
+	}
 	return &BinaryHeap[V]{
 		nodes:        make([]*BinaryHeapNode[V], 1),
 		priorityFunc: priorityFunc,
 		length:       0,
+		arity:        int64(arity),
+		stable:       stable,
 	}
 }
 
-// Returns instance of BinaryHeap. 
-// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
-// Takes O(n) time where n = len(initValues)
-func InitBinaryHeap[V any](priorityFunc func(v1, v2 V) bool, initValues []V) *BinaryHeap[V] {
+func initHeap[V any](priorityFunc func(v1, v2 V) bool, arity int, stable bool, initValues []V) *BinaryHeap[V] {
+	if arity < 2 {
+		panic("arity must be at least 2")
+	}
 	n := len(initValues)
 	bh := &BinaryHeap[V] {
 		nodes: make([]*BinaryHeapNode[V], n+1),
 		priorityFunc: priorityFunc,
 		length: int64(n),
+		arity: int64(arity),
+		stable: stable,
+		nextSeq: int64(n),
 	}
 	for i, v := range initValues {
 		bh.nodes[i+1] = &BinaryHeapNode[V] {
 			index: int64(i+1),
 			binaryHeap: bh,
 			value: v,
+			seq: int64(i),
 		}
 	}
-	for i:=n/2; i>=1; i-- {
-		bh.siftDown(int64(i))
+	for i:=bh.parent(int64(n)); i>=1; i-- {
+		bh.siftDown(i)
 	}
 	return bh
 }
@@ -59,7 +123,9 @@ func (bh *BinaryHeap[V]) Push(value V) *BinaryHeapNode[V] {
 		index:      int64(len(bh.nodes)),
 		binaryHeap: bh,
 		value:      value,
+		seq:        bh.nextSeq,
 	}
+	bh.nextSeq++
 	bh.nodes = append(bh.nodes, newHeapNode)
 	bh.sift(newHeapNode.index)
 	bh.length++
@@ -68,18 +134,50 @@ func (bh *BinaryHeap[V]) Push(value V) *BinaryHeapNode[V] {
 
 // Returns node's pointer of highest priority value. Panics if binary heap is empty. Takes O(1) time.
 func (bh *BinaryHeap[V]) Top() (*BinaryHeapNode[V]) {
+	if bh.length == 0 {
+		panic("binary heap is empty")
+	}
 	return bh.nodes[1]
 }
 
+// Returns node's pointer of highest priority value. Returns (nil, false) if binary heap is empty. Takes O(1) time.
+func (bh *BinaryHeap[V]) TryTop() (*BinaryHeapNode[V], bool) {
+	if bh.length == 0 {
+		return nil, false
+	}
+	return bh.nodes[1], true
+}
+
 // Remove highest priority value and returns it. Panics if binary heap is empty. Takes O(log n) time where n is number of values in the queue.
 func (bh *BinaryHeap[V]) Pop() V {
-	return bh.Remove(bh.nodes[1])
+	return bh.Remove(bh.Top())
+}
+
+// Remove highest priority value and returns it. Returns (zeroValue, false) if binary heap is empty.
+// Takes O(log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) TryPop() (_ V, _ bool) {
+	if bh.length == 0 {
+		return
+	}
+	return bh.Remove(bh.nodes[1]), true
 }
 
-// Deletes node in binary heap. Has no effect if node is already removed. Takes O(log n) time where n is number of values in the queue.
+// Returns true if node is in the binary heap, i.e node is returned by this heap (or moved to it by Merge) and is not removed. Takes O(1) time.
+func (bh *BinaryHeap[V]) Contains(node *BinaryHeapNode[V]) bool {
+	return node.binaryHeap == bh
+}
+
+// Deletes node in binary heap. Has no effect if node is already removed or belongs to another heap. Takes O(log n) time where n is number of values in the queue.
 func (bh *BinaryHeap[V]) Remove(node *BinaryHeapNode[V]) V {
-	if node.binaryHeap == nil {
-		return node.value
+	value, _ := bh.TryRemove(node)
+	return value
+}
+
+// Deletes node in binary heap and returns its value. The second return value is false if node is already removed or belongs to another heap,
+// in which case the binary heap is not modified. Takes O(log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) TryRemove(node *BinaryHeapNode[V]) (V, bool) {
+	if !bh.Contains(node) {
+		return node.value, false
 	}
 	if bh.length == node.index {
 		bh.nodes[bh.length] = nil
@@ -88,7 +186,7 @@ func (bh *BinaryHeap[V]) Remove(node *BinaryHeapNode[V]) V {
 
 		node.binaryHeap = nil
 		node.index = 0
-		return node.value
+		return node.value, true
 	}
 	nodeIndex := node.index
 	bh.swapNodes(bh.length, node.index)
@@ -100,16 +198,23 @@ func (bh *BinaryHeap[V]) Remove(node *BinaryHeapNode[V]) V {
 	node.index = 0
 	
 	bh.sift(nodeIndex)
-	return node.value
+	return node.value, true
 }
 
-// Update node's value to newValue. Has no effect if node is already removed. Takes O(log n) time where n is number of values in the queue.
+// Update node's value to newValue. Has no effect if node is already removed or belongs to another heap. Takes O(log n) time where n is number of values in the queue.
 func (bh *BinaryHeap[V]) Update(node *BinaryHeapNode[V], newValue V) {
-	if node.binaryHeap == nil {
-		return
+	bh.TryUpdate(node, newValue)
+}
+
+// Update node's value to newValue. Returns false if node is already removed or belongs to another heap, in which case neither node nor the binary heap is modified.
+// Takes O(log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) TryUpdate(node *BinaryHeapNode[V], newValue V) bool {
+	if !bh.Contains(node) {
+		return false
 	}
 	node.value = newValue
 	bh.sift(node.index)
+	return true
 }
 
 
@@ -118,8 +223,216 @@ func (bh *BinaryHeap[V]) Len() int64 {
 	return bh.length
 }
 
+// Inserts values into the binary heap and returns node's pointers in the order of values.
+// Takes O(n + k) time if k is large relative to n, otherwise O(k log n) time, where n is number of values in the queue and k = len(values).
+func (bh *BinaryHeap[V]) PushAll(values []V) []*BinaryHeapNode[V] {
+	nodes := make([]*BinaryHeapNode[V], len(values))
+	for i, value := range values {
+		nodes[i] = &BinaryHeapNode[V]{
+			value: value,
+			seq:   bh.nextSeq,
+		}
+		bh.nextSeq++
+	}
+	bh.appendNodes(nodes)
+	return nodes
+}
+
+// Removes at most k highest priority values and returns them in descending order of priority.
+// Takes O(k log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) PopN(k int) []V {
+	if int64(k) > bh.length {
+		k = int(bh.length)
+	}
+	if k <= 0 {
+		return []V{}
+	}
+	values := make([]V, 0, k)
+	for len(values) < k {
+		values = append(values, bh.Pop())
+	}
+	return values
+}
+
+// Inserts value and then removes highest priority value, faster than Push followed by Pop.
+// Returns removed value and node's pointer to pushed value. If value itself has higher priority than every value in the queue,
+// it is returned without being inserted and node's pointer is nil. Takes O(log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) PushPop(value V) (V, *BinaryHeapNode[V]) {
+	if bh.length == 0 || bh.priorityFunc(value, bh.nodes[1].value) {
+		return value, nil
+	}
+	return bh.Replace(value)
+}
+
+// Removes highest priority value and then inserts value, faster than Pop followed by Push.
+// Returns removed value and node's pointer to pushed value. Panics if binary heap is empty.
+// Takes O(log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) Replace(value V) (V, *BinaryHeapNode[V]) {
+	top := bh.Top()
+	newHeapNode := &BinaryHeapNode[V]{
+		index:      1,
+		binaryHeap: bh,
+		value:      value,
+		seq:        bh.nextSeq,
+	}
+	bh.nextSeq++
+	bh.nodes[1] = newHeapNode
+	top.binaryHeap = nil
+	top.index = 0
+	bh.siftDown(1)
+	return top.value, newHeapNode
+}
+
+// Moves all values of other into the binary heap, leaving other empty. Nodes of other remain valid and now belong to the binary heap.
+// In stable heap, values of other are served after values of equal priority already in the binary heap.
+// Takes O(n + m) time if m is large relative to n, otherwise O(m log n) time, where n is number of values in the queue and m is number of values in other.
+func (bh *BinaryHeap[V]) Merge(other *BinaryHeap[V]) {
+	if other == bh {
+		return
+	}
+	nodes := other.nodes[1 : other.length+1]
+	for _, node := range nodes {
+		node.seq += bh.nextSeq
+	}
+	bh.nextSeq += other.nextSeq
+	bh.appendNodes(nodes)
+
+	other.nodes = make([]*BinaryHeapNode[V], 1)
+	other.length = 0
+}
+
+// Returns values of the binary heap in arbitrary order without modifying it. Takes O(n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) Values() []V {
+	values := make([]V, 0, bh.length)
+	for i := int64(1); i <= bh.length; i++ {
+		values = append(values, bh.nodes[i].value)
+	}
+	return values
+}
+
+// Calls fn for every value of the binary heap in arbitrary order until fn returns false. The heap must not be modified by fn.
+func (bh *BinaryHeap[V]) ForEach(fn func(value V) bool) {
+	for i := int64(1); i <= bh.length; i++ {
+		if !fn(bh.nodes[i].value) {
+			return
+		}
+	}
+}
+
+// Returns at most k highest priority values in descending order of priority without modifying the binary heap.
+// Takes O(k log k) time.
+func (bh *BinaryHeap[V]) Peek(k int) []V {
+	if int64(k) > bh.length {
+		k = int(bh.length)
+	}
+	if k <= 0 {
+		return []V{}
+	}
+	values := make([]V, 0, k)
+	iterator := bh.SortedIter()
+	for value, ok := iterator.Key(); ok && len(values) < k; value, ok = iterator.Next() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Iterates values of binary heap in descending order of priority without modifying it.
+// Iterator is invalidated if the binary heap is modified.
+type BinaryHeapSortedIterator[V any] struct {
+	binaryHeap *BinaryHeap[V]
+	// indices of nodes in binary heap that are yet to be visited and whose parents are visited
+	frontier *BinaryHeap[int64]
+}
+
+// Returns iterator pointing to highest priority value. First k values are visited in O(k log k) time as only
+// children of visited nodes are kept in an auxiliary heap.
+func (bh *BinaryHeap[V]) SortedIter() *BinaryHeapSortedIterator[V] {
+	frontier := NewBinaryHeap[int64](func(id1, id2 int64) bool {
+		return bh.higher(id1, id2)
+	})
+	if bh.length > 0 {
+		frontier.Push(1)
+	}
+	return &BinaryHeapSortedIterator[V]{
+		binaryHeap: bh,
+		frontier:   frontier,
+	}
+}
+
+// Returns value pointed by the iterator. Returns (zeroValue, false) if all values are visited.
+func (bhsi *BinaryHeapSortedIterator[V]) Key() (_ V, _ bool) {
+	if bhsi.frontier.Len() == 0 {
+		return
+	}
+	return bhsi.binaryHeap.nodes[bhsi.frontier.Top().value].value, true
+}
+
+// Moves the iterator to the next value in descending order of priority and returns it.
+// If Next() is called on lowest priority value, it returns (zeroValue, false). Takes O(log k) time where k is number of visited values.
+func (bhsi *BinaryHeapSortedIterator[V]) Next() (_ V, _ bool) {
+	if bhsi.frontier.Len() == 0 {
+		return
+	}
+	bh := bhsi.binaryHeap
+	nodeIndex := bhsi.frontier.Pop()
+	childIndex := bh.firstChild(nodeIndex)
+	for lastChildIndex := childIndex + bh.arity - 1; childIndex <= lastChildIndex && childIndex <= bh.length; childIndex++ {
+		bhsi.frontier.Push(childIndex)
+	}
+	return bhsi.Key()
+}
+
+// appends detached nodes to the binary heap and restores heap property either by heapify or by sifting up every node, whichever is cheaper
+func (bh *BinaryHeap[V]) appendNodes(nodes []*BinaryHeapNode[V]) {
+	k := int64(len(nodes))
+	for _, node := range nodes {
+		bh.length++
+		node.index = bh.length
+		node.binaryHeap = bh
+		bh.nodes = append(bh.nodes, node)
+	}
+	if k*int64(bits.Len64(uint64(bh.length))) > bh.length {
+		for i := bh.parent(bh.length); i >= 1; i-- {
+			bh.siftDown(i)
+		}
+		return
+	}
+	for _, node := range nodes {
+		bh.siftUp(node.index)
+	}
+}
+
+// Returns index of parent node. Returns 0 for root node
+func (bh *BinaryHeap[V]) parent(nodeIndex int64) int64 {
+	if bh.arity == 2 {
+		return nodeIndex >> 1
+	}
+	if nodeIndex <= 1 {
+		return 0
+	}
+	return (nodeIndex-2)/bh.arity + 1
+}
+
+// Returns index of first child node. Children of a node occupy consecutive indices
+func (bh *BinaryHeap[V]) firstChild(nodeIndex int64) int64 {
+	if bh.arity == 2 {
+		return nodeIndex << 1
+	}
+	return bh.arity*(nodeIndex-1) + 2
+}
+
+// Returns true if node at id1 has higher priority than node at id2. In stable heap, ties are broken by insertion order
+func (bh *BinaryHeap[V]) higher(id1, id2 int64) bool {
+	node1, node2 := bh.nodes[id1], bh.nodes[id2]
+	if bh.priorityFunc(node1.value, node2.value) {
+		return true
+	}
+	return bh.stable && node1.seq < node2.seq && !bh.priorityFunc(node2.value, node1.value)
+}
+
 func (bh *BinaryHeap[V]) sift(nodeIndex int64) {
-	isSiftUp := (nodeIndex >> 1) > 0 && bh.priorityFunc(bh.nodes[nodeIndex].GetValue(), bh.nodes[nodeIndex >> 1].GetValue())
+	parentIndex := bh.parent(nodeIndex)
+	isSiftUp := parentIndex > 0 && bh.higher(nodeIndex, parentIndex)
 	if isSiftUp {
 		bh.siftUp(nodeIndex)
 		return
@@ -128,23 +441,29 @@ func (bh *BinaryHeap[V]) sift(nodeIndex int64) {
 }
 
 func (bh *BinaryHeap[V]) siftUp(nodeIndex int64) {
-	for nodeIndex > 1 && bh.priorityFunc(bh.nodes[nodeIndex].GetValue(), bh.nodes[nodeIndex >> 1].GetValue()) {
-		bh.swapNodes(nodeIndex, nodeIndex >> 1)
-		nodeIndex >>= 1
+	for nodeIndex > 1 {
+		parentIndex := bh.parent(nodeIndex)
+		if !bh.higher(nodeIndex, parentIndex) {
+			return
+		}
+		bh.swapNodes(nodeIndex, parentIndex)
+		nodeIndex = parentIndex
 	}
 }
 
 func (bh *BinaryHeap[V]) siftDown(nodeIndex int64) {
 	for nodeIndex <= bh.length {
 		priorIndex := nodeIndex
-		leftIndex := nodeIndex << 1
-		rightIndex := (nodeIndex << 1) + 1
-
-		if leftIndex <= bh.length && bh.priorityFunc(bh.nodes[leftIndex].GetValue(), bh.nodes[priorIndex].GetValue()) {
-			priorIndex = leftIndex
+		childIndex := bh.firstChild(nodeIndex)
+		lastChildIndex := childIndex + bh.arity - 1
+		if lastChildIndex > bh.length {
+			lastChildIndex = bh.length
 		}
-		if rightIndex <= bh.length && bh.priorityFunc(bh.nodes[rightIndex].GetValue(), bh.nodes[priorIndex].GetValue()) {
-			priorIndex = rightIndex
+
+		for ; childIndex <= lastChildIndex; childIndex++ {
+			if bh.higher(childIndex, priorIndex) {
+				priorIndex = childIndex
+			}
 		}
 
 		if priorIndex == nodeIndex {
@@ -1,10 +1,17 @@
 package priorityqueue_test
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
 	"sort"
 	"testing"
 
 	"github.com/storybehind/gocontainer/priorityqueue"
+	"github.com/storybehind/gocontainer/priorityqueue/priorityqueuetest"
 )
 
 func TestNewBinaryHeap(t *testing.T) {
@@ -56,6 +63,15 @@ func TestNewBinaryHeap(t *testing.T) {
 	checkLen(t, minHeap, 0)
 }
 
+func TestBinaryHeap(t *testing.T) {
+	priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, *priorityqueue.BinaryHeapNode[int]] {
+		return priorityqueue.NewBinaryHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	})
+	priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, *priorityqueue.BinaryHeapNode[int]] {
+		return priorityqueue.NewDaryHeap[int](func(v1, v2 int) bool { return v1 < v2 }, 4)
+	})
+}
+
 func TestBinaryHeapInit(t *testing.T) {
 	initValues := []int{4, 1, 3, 2, 5, 6, 5}
 	bh := priorityqueue.InitBinaryHeap[int](func(v1, v2 int) bool {return v1 < v2}, initValues)
@@ -73,6 +89,69 @@ func TestBinaryHeapInit(t *testing.T) {
 	}
 }
 
+func TestDaryHeap(t *testing.T) {
+	for arity := 2; arity <= 5; arity++ {
+		r := rand.New(rand.NewSource(int64(arity)))
+		initValues := make([]int, 50)
+		for i := range initValues {
+			initValues[i] = r.Intn(1000)
+		}
+		bh := priorityqueue.InitDaryHeap[int](func(v1, v2 int) bool { return v1 < v2 }, arity, initValues)
+		nodes := make([]*priorityqueue.BinaryHeapNode[int], 0)
+		for i := 0; i < 50; i++ {
+			nodes = append(nodes, bh.Push(r.Intn(1000)))
+		}
+		// decrease half of pushed values and remove a quarter of them
+		for i := 0; i < len(nodes); i += 2 {
+			bh.Update(nodes[i], nodes[i].GetValue()-r.Intn(1000))
+		}
+		for i := 1; i < len(nodes); i += 4 {
+			bh.Remove(nodes[i])
+		}
+
+		expValues := append([]int{}, initValues...)
+		for i, node := range nodes {
+			if i%4 != 1 {
+				expValues = append(expValues, node.GetValue())
+			}
+		}
+		sort.Ints(expValues)
+		checkLen(t, bh, int64(len(expValues)))
+		for _, expValue := range expValues {
+			checkPop(t, bh, expValue)
+		}
+	}
+}
+
+func BenchmarkDaryHeap(b *testing.B) {
+	const n = 10000
+	for _, arity := range []int{2, 3, 4, 8} {
+		b.Run(fmt.Sprintf("arity=%d", arity), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			values := make([]int, n)
+			for i := range values {
+				values[i] = r.Intn(1 << 30)
+			}
+			nodes := make([]*priorityqueue.BinaryHeapNode[int], n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// push every value, decrease every value once and pop all values like in dijkstra
+				bh := priorityqueue.NewDaryHeap[int](func(v1, v2 int) bool { return v1 < v2 }, arity)
+				for j, v := range values {
+					nodes[j] = bh.Push(v)
+				}
+				for _, node := range nodes {
+					bh.Update(node, node.GetValue()>>1)
+				}
+				for bh.Len() > 0 {
+					bh.Pop()
+				}
+			}
+		})
+	}
+}
+
 func checkLen[V any](t *testing.T, bh *priorityqueue.BinaryHeap[V], len int64) {
 	if n := bh.Len(); n != len {
 		t.Errorf("mh.Len() = %d, want= %d", n, len)
@@ -99,3 +178,383 @@ func checkRemove[V comparable](t *testing.T, bh *priorityqueue.BinaryHeap[V], bh
 		t.Errorf("val = %v, bhn.GetValue() = %v ; val & bhn.GetValue() must match after removal", val, bhn.GetValue())
 	}
 }
+
+func TestStableBinaryHeap(t *testing.T) {
+	type job struct {
+		priority int
+		id       int
+	}
+	priorityFunc := func(j1, j2 job) bool { return j1.priority > j2.priority }
+	assertPopOrder := func(bh *priorityqueue.BinaryHeap[job], expIds []int) {
+		for _, expId := range expIds {
+			if j := bh.Pop(); j.id != expId {
+				t.Errorf("found job id = %d, expected = %d", j.id, expId)
+			}
+		}
+	}
+
+	bh := priorityqueue.NewStableBinaryHeap[job](priorityFunc)
+	nodes := make([]*priorityqueue.BinaryHeapNode[job], 0)
+	for id := 0; id < 20; id++ {
+		nodes = append(nodes, bh.Push(job{priority: id % 2, id: id}))
+	}
+	// moving job 2 to priority 1 and back retains its insertion order
+	bh.Update(nodes[2], job{priority: 1, id: 2})
+	bh.Update(nodes[2], job{priority: 0, id: 2})
+	// job 4 is now served along with priority 1 jobs in insertion order
+	bh.Update(nodes[4], job{priority: 1, id: 4})
+	assertPopOrder(bh, []int{1, 3, 4, 5, 7, 9, 11, 13, 15, 17, 19, 0, 2, 6, 8, 10, 12, 14, 16, 18})
+
+	initJobs := make([]job, 0)
+	for id := 0; id < 20; id++ {
+		initJobs = append(initJobs, job{priority: id % 3, id: id})
+	}
+	bh = priorityqueue.InitStableBinaryHeap[job](priorityFunc, initJobs)
+	bh.Push(job{priority: 2, id: 20})
+	assertPopOrder(bh, []int{2, 5, 8, 11, 14, 17, 20, 1, 4, 7, 10, 13, 16, 19, 0, 3, 6, 9, 12, 15, 18})
+}
+
+func TestBinaryHeapIteration(t *testing.T) {
+	for arity := 2; arity <= 4; arity++ {
+		r := rand.New(rand.NewSource(int64(arity)))
+		initValues := make([]int, 100)
+		for i := range initValues {
+			initValues[i] = r.Intn(50)
+		}
+		bh := priorityqueue.InitDaryHeap[int](func(v1, v2 int) bool { return v1 < v2 }, arity, initValues)
+		expValues := append([]int{}, initValues...)
+		sort.Ints(expValues)
+
+		values := bh.Values()
+		sort.Ints(values)
+		if fmt.Sprint(values) != fmt.Sprint(expValues) {
+			t.Errorf("arity %d: sorted Values() = %v, exp = %v", arity, values, expValues)
+		}
+		visited := 0
+		bh.ForEach(func(value int) bool {
+			visited++
+			return visited < 10
+		})
+		if visited != 10 {
+			t.Errorf("arity %d: ForEach visited %d values after returning false, exp = 10", arity, visited)
+		}
+
+		sorted := make([]int, 0)
+		iterator := bh.SortedIter()
+		for value, ok := iterator.Key(); ok; value, ok = iterator.Next() {
+			sorted = append(sorted, value)
+		}
+		if fmt.Sprint(sorted) != fmt.Sprint(expValues) {
+			t.Errorf("arity %d: SortedIter() visited %v, exp = %v", arity, sorted, expValues)
+		}
+		for _, k := range []int{0, 1, 7, 100, 150} {
+			expPeek := expValues
+			if k < len(expValues) {
+				expPeek = expValues[:k]
+			}
+			if peek := bh.Peek(k); fmt.Sprint(peek) != fmt.Sprint(expPeek) {
+				t.Errorf("arity %d: Peek(%d) = %v, exp = %v", arity, k, peek, expPeek)
+			}
+		}
+
+		checkLen(t, bh, int64(len(expValues)))
+		for _, expValue := range expValues {
+			checkPop(t, bh, expValue)
+		}
+		if _, ok := bh.SortedIter().Key(); ok {
+			t.Errorf("arity %d: SortedIter() of empty heap; exp ok = false", arity)
+		}
+	}
+}
+
+func TestBinaryHeapBatch(t *testing.T) {
+	priorityFunc := func(v1, v2 int) bool { return v1 < v2 }
+	r := rand.New(rand.NewSource(1))
+	randomValues := func(n int) []int {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = r.Intn(1000)
+		}
+		return values
+	}
+
+	// few values pushed to a large heap are sifted up, many values are heapified
+	for _, k := range []int{3, 500} {
+		initValues := randomValues(100)
+		bh := priorityqueue.InitBinaryHeap[int](priorityFunc, initValues)
+		values := randomValues(k)
+		nodes := bh.PushAll(values)
+		for i, node := range nodes {
+			if node.GetValue() != values[i] {
+				t.Errorf("PushAll() node %d has value %d, exp = %d", i, node.GetValue(), values[i])
+			}
+		}
+		bh.Update(nodes[0], -1)
+		bh.Remove(nodes[1])
+		expValues := append(append([]int{-1}, initValues...), values[2:]...)
+		sort.Ints(expValues)
+		if popped := bh.PopN(10); fmt.Sprint(popped) != fmt.Sprint(expValues[:10]) {
+			t.Errorf("PopN(10) = %v, exp = %v", popped, expValues[:10])
+		}
+		checkLen(t, bh, int64(len(expValues)-10))
+		for _, expValue := range expValues[10:] {
+			checkPop(t, bh, expValue)
+		}
+		if popped := bh.PopN(1); len(popped) != 0 {
+			t.Errorf("PopN(1) on empty heap = %v, exp = []", popped)
+		}
+	}
+
+	bh := priorityqueue.InitBinaryHeap[int](priorityFunc, []int{5, 3, 8})
+	if v, node := bh.PushPop(1); v != 1 || node != nil {
+		t.Errorf("PushPop(1) = (%d, %v), exp = (1, nil)", v, node)
+	}
+	v, node := bh.PushPop(4)
+	if v != 3 || node.GetValue() != 4 {
+		t.Errorf("PushPop(4) = (%d, %v), exp = (3, 4)", v, node.GetValue())
+	}
+	if v, _ := bh.Replace(9); v != 4 {
+		t.Errorf("Replace(9) = %d, exp = 4", v)
+	}
+	bh.Update(node, 0)
+	checkLen(t, bh, 3)
+	for _, expValue := range []int{5, 8, 9} {
+		checkPop(t, bh, expValue)
+	}
+
+	defer func() {
+		if r := recover(); r != "binary heap is empty" {
+			t.Errorf("Replace() on empty heap panicked with %v; exp = binary heap is empty", r)
+		}
+	}()
+	bh.Replace(1)
+}
+
+func TestBinaryHeapMerge(t *testing.T) {
+	priorityFunc := func(v1, v2 int) bool { return v1 < v2 }
+	bh := priorityqueue.InitBinaryHeap[int](priorityFunc, []int{4, 8, 2})
+	other := priorityqueue.NewBinaryHeap[int](priorityFunc)
+	nodes := other.PushAll([]int{7, 1, 5})
+	bh.Merge(other)
+	bh.Merge(bh)
+	checkLen(t, other, 0)
+	bh.Update(nodes[0], 0)
+	checkRemove(t, bh, nodes[1])
+	checkLen(t, bh, 5)
+	for _, expValue := range []int{0, 2, 4, 5, 8} {
+		checkPop(t, bh, expValue)
+	}
+
+	type job struct {
+		priority int
+		id       int
+	}
+	stablePriorityFunc := func(j1, j2 job) bool { return j1.priority > j2.priority }
+	stable := priorityqueue.InitStableBinaryHeap[job](stablePriorityFunc, []job{{1, 0}, {0, 1}, {1, 2}})
+	stableOther := priorityqueue.InitStableBinaryHeap[job](stablePriorityFunc, []job{{0, 3}, {1, 4}})
+	stable.Merge(stableOther)
+	stable.Push(job{1, 5})
+	for _, expId := range []int{0, 2, 4, 5, 1, 3} {
+		if j := stable.Pop(); j.id != expId {
+			t.Errorf("found job id = %d, expected = %d", j.id, expId)
+		}
+	}
+}
+
+func TestBinaryHeapTry(t *testing.T) {
+	priorityFunc := func(v1, v2 int) bool { return v1 < v2 }
+	bh := priorityqueue.NewBinaryHeap[int](priorityFunc)
+	if node, ok := bh.TryTop(); node != nil || ok {
+		t.Errorf("TryTop() on empty heap = (%v, %v), exp = (nil, false)", node, ok)
+	}
+	if v, ok := bh.TryPop(); v != 0 || ok {
+		t.Errorf("TryPop() on empty heap = (%d, %v), exp = (0, false)", v, ok)
+	}
+	node := bh.Push(2)
+	bh.Push(1)
+	if top, ok := bh.TryTop(); !ok || top.GetValue() != 1 {
+		t.Errorf("TryTop() = (%v, %v), exp = (1, true)", top.GetValue(), ok)
+	}
+	if v, ok := bh.TryPop(); v != 1 || !ok {
+		t.Errorf("TryPop() = (%d, %v), exp = (1, true)", v, ok)
+	}
+	if !bh.Contains(node) {
+		t.Errorf("Contains() = false for pushed node; exp = true")
+	}
+	if v, ok := bh.TryRemove(node); v != 2 || !ok {
+		t.Errorf("TryRemove() = (%d, %v), exp = (2, true)", v, ok)
+	}
+	if bh.Contains(node) {
+		t.Errorf("Contains() = true for removed node; exp = false")
+	}
+	if _, ok := bh.TryRemove(node); ok {
+		t.Errorf("TryRemove() of removed node; exp ok = false")
+	}
+	if bh.TryUpdate(node, 0) {
+		t.Errorf("TryUpdate() of removed node = true; exp = false")
+	}
+}
+
+func TestBinaryHeapForeignNode(t *testing.T) {
+	priorityFunc := func(v1, v2 int) bool { return v1 < v2 }
+	bh1 := priorityqueue.InitBinaryHeap[int](priorityFunc, []int{3, 5, 7})
+	bh2 := priorityqueue.InitBinaryHeap[int](priorityFunc, []int{4, 6})
+	foreign := bh2.Push(1)
+
+	if bh1.Contains(foreign) {
+		t.Errorf("Contains() = true for node of another heap; exp = false")
+	}
+	if v, ok := bh1.TryRemove(foreign); v != 1 || ok {
+		t.Errorf("TryRemove() of foreign node = (%d, %v), exp = (1, false)", v, ok)
+	}
+	if bh1.TryUpdate(foreign, 10) {
+		t.Errorf("TryUpdate() of foreign node = true; exp = false")
+	}
+	bh1.Remove(foreign)
+	bh1.Update(foreign, 10)
+	if foreign.GetValue() != 1 {
+		t.Errorf("foreign node value = %d, exp = 1", foreign.GetValue())
+	}
+
+	checkLen(t, bh1, 3)
+	for _, expValue := range []int{3, 5, 7} {
+		checkPop(t, bh1, expValue)
+	}
+	checkLen(t, bh2, 3)
+	for _, expValue := range []int{1, 4, 6} {
+		checkPop(t, bh2, expValue)
+	}
+
+	// merged node belongs to the heap it is merged into
+	bh1.Push(2)
+	merged := bh2.Push(8)
+	bh1.Merge(bh2)
+	if bh2.Contains(merged) || !bh1.Contains(merged) {
+		t.Errorf("merged node should belong to heap it is merged into")
+	}
+}
+
+func TestBinaryHeapJSON(t *testing.T) {
+	priorityFunc := func(v1, v2 int) bool { return v1 < v2 }
+	bh := priorityqueue.InitBinaryHeap[int](priorityFunc, []int{5, 1, 4, 2})
+	data, err := json.Marshal(bh)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v", err)
+	}
+	if string(data) != "[1,2,4,5]" {
+		t.Errorf("Marshal() = %s, exp = [1,2,4,5]", data)
+	}
+	checkLen(t, bh, 4)
+
+	decoded := priorityqueue.NewDaryHeap[int](priorityFunc, 3)
+	node := decoded.Push(0)
+	if err := json.Unmarshal([]byte("[9, 3, 7, 3]"), decoded); err != nil {
+		t.Fatalf("Unmarshal() err = %v", err)
+	}
+	if decoded.Contains(node) {
+		t.Errorf("node pushed before Unmarshal should be removed")
+	}
+	checkLen(t, decoded, 4)
+	for _, expValue := range []int{3, 3, 7, 9} {
+		checkPop(t, decoded, expValue)
+	}
+
+	var uninitialized priorityqueue.BinaryHeap[int]
+	if err := json.Unmarshal(data, &uninitialized); err == nil {
+		t.Errorf("Unmarshal() into heap not created by constructor; exp error")
+	}
+}
+
+func TestBinaryHeapBinary(t *testing.T) {
+	type job struct {
+		Priority int
+		Id       int
+	}
+	priorityFunc := func(j1, j2 job) bool { return j1.Priority > j2.Priority }
+	bh := priorityqueue.NewStableBinaryHeap[job](priorityFunc)
+	for id := 0; id < 20; id++ {
+		bh.Push(job{Priority: id % 3, Id: id})
+	}
+	bh.Pop()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(bh); err != nil {
+		t.Fatalf("Encode() err = %v", err)
+	}
+
+	decoded := priorityqueue.NewStableBinaryHeap[job](priorityFunc)
+	node := decoded.Push(job{})
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatalf("Decode() err = %v", err)
+	}
+	if decoded.Contains(node) {
+		t.Errorf("node pushed before decoding should be removed")
+	}
+	// insertion order of equal priority values is kept
+	decoded.Push(job{Priority: 2, Id: 20})
+	for _, expId := range []int{5, 8, 11, 14, 17, 20, 1, 4, 7, 10, 13, 16, 19, 0, 3, 6, 9, 12, 15, 18} {
+		if j := decoded.Pop(); j.Id != expId {
+			t.Errorf("found job id = %d, expected = %d", j.Id, expId)
+		}
+	}
+	if err := decoded.UnmarshalBinary([]byte("junk")); err == nil {
+		t.Errorf("UnmarshalBinary() of junk; exp error")
+	}
+}
+
+func TestBinaryHeapFormat(t *testing.T) {
+	bh := priorityqueue.InitBinaryHeap[int](func(v1, v2 int) bool { return v1 < v2 }, []int{5, 3, 4, 1, 2})
+	tests := []struct {
+		format string
+		exp    string
+	}{
+		{"%v", "[1 2 3 4 5]"},
+		{"%.2v", "[1 2 ...(3 more)]"},
+		{"%+v", "BinaryHeap{arity:2 stable:false nodes:[1 2 4 3 5]}"},
+	}
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, bh); s != test.exp {
+			t.Errorf("Sprintf(%s) = %s, exp = %s", test.format, s, test.exp)
+		}
+	}
+	checkLen(t, bh, 5)
+}
+
+func TestBinaryHeapValidate(t *testing.T) {
+	// reversing priority after pushes breaks heap property
+	reversed := false
+	bh := priorityqueue.NewBinaryHeap[int](func(v1, v2 int) bool { return (v1 < v2) != reversed })
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		bh.Push(v)
+	}
+	if err := bh.Validate(); err != nil {
+		t.Fatalf("Validate() err = %v", err)
+	}
+	reversed = true
+	if err := bh.Validate(); !errors.Is(err, priorityqueue.ErrInvalidHeap) {
+		t.Errorf("Validate() err = %v, exp = %v", err, priorityqueue.ErrInvalidHeap)
+	}
+}
+
+func TestMinHeapAndMaxHeap(t *testing.T) {
+	values := []float64{3, -1, 7.5, 0, 2}
+	minHeap, maxHeap := priorityqueue.NewMinHeap[float64](), priorityqueue.NewMaxHeap[float64]()
+	for _, v := range values {
+		minHeap.Push(v)
+		maxHeap.Push(v)
+	}
+	tests := []struct {
+		name string
+		bh   *priorityqueue.BinaryHeap[float64]
+		exp  []float64
+	}{
+		{"NewMinHeap", minHeap, []float64{-1, 0, 2, 3, 7.5}},
+		{"NewMaxHeap", maxHeap, []float64{7.5, 3, 2, 0, -1}},
+		{"InitMinHeap", priorityqueue.InitMinHeap[float64](values), []float64{-1, 0, 2, 3, 7.5}},
+		{"InitMaxHeap", priorityqueue.InitMaxHeap[float64](values), []float64{7.5, 3, 2, 0, -1}},
+	}
+	for _, test := range tests {
+		if got := test.bh.PopN(len(values)); fmt.Sprint(got) != fmt.Sprint(test.exp) {
+			t.Errorf("%s popped %v, exp = %v", test.name, got, test.exp)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned on pushing to a closed queue or popping from a closed and empty queue
+var ErrClosed = errors.New("priorityqueue: queue is closed")
+
+// Goroutine safe priority queue backed by BinaryHeap.
+// Pop blocks until a value is available and Push blocks while queue is full, if capacity is set.
+type BlockingPriorityQueue[V any] struct {
+	mu       sync.Mutex
+	bh       *BinaryHeap[V]
+	capacity int64
+	closed   bool
+	// closed and replaced whenever queue changes to wake up waiting goroutines
+	changed chan struct{}
+}
+
+// Returns instance of unbounded BlockingPriorityQueue.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+func NewBlockingPriorityQueue[V any](priorityFunc func(v1, v2 V) bool) *BlockingPriorityQueue[V] {
+	return &BlockingPriorityQueue[V]{
+		bh:      NewBinaryHeap[V](priorityFunc),
+		changed: make(chan struct{}),
+	}
+}
+
+// Returns instance of BlockingPriorityQueue that holds at most capacity values.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// panics if capacity is less than 1
+func NewBoundedBlockingPriorityQueue[V any](priorityFunc func(v1, v2 V) bool, capacity int) *BlockingPriorityQueue[V] {
+	if capacity < 1 {
+		panic("capacity must be at least 1")
+	}
+	bpq := NewBlockingPriorityQueue[V](priorityFunc)
+	bpq.capacity = int64(capacity)
+	return bpq
+}
+
+// Insert value into the queue and returns node's pointer to pushed value. Blocks while queue is full.
+// Returns ErrClosed if queue is closed, or ctx.Err() if ctx is done before value is pushed.
+func (bpq *BlockingPriorityQueue[V]) Push(ctx context.Context, value V) (*BinaryHeapNode[V], error) {
+	for {
+		bpq.mu.Lock()
+		if bpq.closed {
+			bpq.mu.Unlock()
+			return nil, ErrClosed
+		}
+		if !bpq.isFull() {
+			node := bpq.bh.Push(value)
+			bpq.notify()
+			bpq.mu.Unlock()
+			return node, nil
+		}
+		changed := bpq.changed
+		bpq.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Insert value into the queue without blocking and returns node's pointer to pushed value.
+// Returns (nil, false) if queue is full or closed.
+func (bpq *BlockingPriorityQueue[V]) TryPush(value V) (*BinaryHeapNode[V], bool) {
+	bpq.mu.Lock()
+	defer bpq.mu.Unlock()
+	if bpq.closed || bpq.isFull() {
+		return nil, false
+	}
+	node := bpq.bh.Push(value)
+	bpq.notify()
+	return node, true
+}
+
+// Remove highest priority value and returns it. Blocks while queue is empty.
+// Once queue is closed, remaining values are still served and ErrClosed is returned after queue is drained.
+// Returns ctx.Err() if ctx is done before a value is available.
+func (bpq *BlockingPriorityQueue[V]) Pop(ctx context.Context) (_ V, _ error) {
+	for {
+		bpq.mu.Lock()
+		if bpq.bh.Len() > 0 {
+			value := bpq.bh.Pop()
+			bpq.notify()
+			bpq.mu.Unlock()
+			return value, nil
+		}
+		if bpq.closed {
+			bpq.mu.Unlock()
+			return *new(V), ErrClosed
+		}
+		changed := bpq.changed
+		bpq.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return *new(V), ctx.Err()
+		}
+	}
+}
+
+// Remove highest priority value without blocking and returns it. Returns (zeroValue, false) if queue is empty.
+func (bpq *BlockingPriorityQueue[V]) TryPop() (_ V, _ bool) {
+	bpq.mu.Lock()
+	defer bpq.mu.Unlock()
+	if bpq.bh.Len() == 0 {
+		return
+	}
+	value := bpq.bh.Pop()
+	bpq.notify()
+	return value, true
+}
+
+// Deletes node in the queue. Has no effect if node is already removed.
+func (bpq *BlockingPriorityQueue[V]) Remove(node *BinaryHeapNode[V]) V {
+	bpq.mu.Lock()
+	defer bpq.mu.Unlock()
+	value := bpq.bh.Remove(node)
+	bpq.notify()
+	return value
+}
+
+// Update node's value to newValue. Has no effect if node is already removed.
+// Reading node's value with GetValue() concurrently with Update is not safe.
+func (bpq *BlockingPriorityQueue[V]) Update(node *BinaryHeapNode[V], newValue V) {
+	bpq.mu.Lock()
+	defer bpq.mu.Unlock()
+	bpq.bh.Update(node, newValue)
+}
+
+// Closes the queue. Blocked Push calls return ErrClosed and blocked Pop calls return ErrClosed once queue is drained.
+// Calling Close more than once has no effect.
+func (bpq *BlockingPriorityQueue[V]) Close() {
+	bpq.mu.Lock()
+	defer bpq.mu.Unlock()
+	if bpq.closed {
+		return
+	}
+	bpq.closed = true
+	bpq.notify()
+}
+
+// Returns number of values currently in the queue
+func (bpq *BlockingPriorityQueue[V]) Len() int64 {
+	bpq.mu.Lock()
+	defer bpq.mu.Unlock()
+	return bpq.bh.Len()
+}
+
+func (bpq *BlockingPriorityQueue[V]) isFull() bool {
+	return bpq.capacity > 0 && bpq.bh.Len() >= bpq.capacity
+}
+
+// wakes up all waiting goroutines. Must be called with mu held
+func (bpq *BlockingPriorityQueue[V]) notify() {
+	close(bpq.changed)
+	bpq.changed = make(chan struct{})
+}
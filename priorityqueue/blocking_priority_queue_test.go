@@ -0,0 +1,120 @@
+package priorityqueue_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+func TestBlockingPriorityQueuePop(t *testing.T) {
+	bpq := priorityqueue.NewBlockingPriorityQueue[int](func(v1, v2 int) bool { return v1 < v2 })
+	popped := make(chan int)
+	go func() {
+		v, err := bpq.Pop(context.Background())
+		if err != nil {
+			t.Errorf("Pop() err = %v", err)
+		}
+		popped <- v
+	}()
+	if _, err := bpq.Push(context.Background(), 5); err != nil {
+		t.Fatalf("Push() err = %v", err)
+	}
+	if v := <-popped; v != 5 {
+		t.Errorf("Pop() = %d, exp = 5", v)
+	}
+
+	if _, ok := bpq.TryPop(); ok {
+		t.Errorf("TryPop() on empty queue; exp ok = false")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := bpq.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pop() on empty queue err = %v, exp = %v", err, context.DeadlineExceeded)
+	}
+
+	node, _ := bpq.TryPush(3)
+	bpq.TryPush(2)
+	bpq.Update(node, 1)
+	if v, ok := bpq.TryPop(); v != 1 || !ok {
+		t.Errorf("TryPop() = (%d, %v), exp = (1, true)", v, ok)
+	}
+	bpq.Remove(node)
+	if bpq.Len() != 1 {
+		t.Errorf("Len() = %d, exp = 1", bpq.Len())
+	}
+}
+
+func TestBlockingPriorityQueueCapacity(t *testing.T) {
+	bpq := priorityqueue.NewBoundedBlockingPriorityQueue[int](func(v1, v2 int) bool { return v1 < v2 }, 2)
+	bpq.TryPush(1)
+	bpq.TryPush(2)
+	if _, ok := bpq.TryPush(3); ok {
+		t.Errorf("TryPush() on full queue; exp ok = false")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := bpq.Push(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Push() on full queue err = %v, exp = %v", err, context.DeadlineExceeded)
+	}
+
+	pushed := make(chan struct{})
+	go func() {
+		if _, err := bpq.Push(context.Background(), 0); err != nil {
+			t.Errorf("Push() err = %v", err)
+		}
+		close(pushed)
+	}()
+	if v, _ := bpq.Pop(context.Background()); v != 1 {
+		t.Errorf("Pop() = %d, exp = 1", v)
+	}
+	<-pushed
+	if v, _ := bpq.Pop(context.Background()); v != 0 {
+		t.Errorf("Pop() = %d, exp = 0", v)
+	}
+}
+
+func TestBlockingPriorityQueueClose(t *testing.T) {
+	bpq := priorityqueue.NewBlockingPriorityQueue[int](func(v1, v2 int) bool { return v1 < v2 })
+	const workers, values = 4, 1000
+	var mu sync.Mutex
+	popped := make([]int, 0, values)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, err := bpq.Pop(context.Background())
+				if errors.Is(err, priorityqueue.ErrClosed) {
+					return
+				}
+				mu.Lock()
+				popped = append(popped, v)
+				mu.Unlock()
+			}
+		}()
+	}
+	for v := 0; v < values; v++ {
+		bpq.Push(context.Background(), v)
+	}
+	bpq.Close()
+	wg.Wait()
+
+	if _, err := bpq.Push(context.Background(), 0); !errors.Is(err, priorityqueue.ErrClosed) {
+		t.Errorf("Push() on closed queue err = %v, exp = %v", err, priorityqueue.ErrClosed)
+	}
+	sort.Ints(popped)
+	if len(popped) != values {
+		t.Fatalf("popped %d values, exp = %d", len(popped), values)
+	}
+	for i, v := range popped {
+		if v != i {
+			t.Fatalf("popped[%d] = %d, exp = %d", i, v, i)
+		}
+	}
+}
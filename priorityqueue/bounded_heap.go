@@ -0,0 +1,152 @@
+package priorityqueue
+
+import "sort"
+
+// Keeps at most capacity highest priority values among all values offered to it (top-k).
+// Internally maintains BinaryHeap with lowest priority kept value on top, so that it can be evicted in O(log k) time where k is capacity.
+type BoundedHeap[V any] struct {
+	bh           *BinaryHeap[V]
+	priorityFunc func(v1, v2 V) bool
+	capacity     int64
+}
+
+// Returns instance of BoundedHeap that keeps at most capacity values.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// panics if capacity is less than 1
+func NewBoundedHeap[V any](priorityFunc func(v1, v2 V) bool, capacity int) *BoundedHeap[V] {
+	if capacity < 1 {
+		panic("capacity must be at least 1")
+	}
+	return &BoundedHeap[V]{
+		bh:           NewBinaryHeap[V](lowerPriorityFunc(priorityFunc)),
+		priorityFunc: priorityFunc,
+		capacity:     int64(capacity),
+	}
+}
+
+// Returns instance of BoundedHeap that keeps at most capacity highest priority values of initValues.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// Takes O(n) expected time where n = len(initValues). initValues is not modified.
+// panics if capacity is less than 1
+func InitBoundedHeap[V any](priorityFunc func(v1, v2 V) bool, capacity int, initValues []V) *BoundedHeap[V] {
+	if capacity < 1 {
+		panic("capacity must be at least 1")
+	}
+	values := append([]V{}, initValues...)
+	if len(values) > capacity {
+		selectHighest(values, capacity, priorityFunc)
+		values = values[:capacity]
+	}
+	return &BoundedHeap[V]{
+		bh:           InitBinaryHeap[V](lowerPriorityFunc(priorityFunc), values),
+		priorityFunc: priorityFunc,
+		capacity:     int64(capacity),
+	}
+}
+
+// Offers value to the bounded heap. Takes O(log k) time where k is capacity.
+// If the bounded heap is not full, value is kept and returns (zeroValue, false).
+// Otherwise, lowest priority value among kept values and given value is evicted and returned, and the second return value is true.
+// When value does not have higher priority than every kept value, value itself is returned.
+func (bdh *BoundedHeap[V]) Offer(value V) (evicted V, ok bool) {
+	if bdh.bh.Len() < bdh.capacity {
+		bdh.bh.Push(value)
+		return
+	}
+	bottom := bdh.bh.Top()
+	if !bdh.priorityFunc(value, bottom.GetValue()) {
+		return value, true
+	}
+	evicted = bottom.GetValue()
+	bdh.bh.Update(bottom, value)
+	return evicted, true
+}
+
+// Returns lowest priority value among kept values. Panics if bounded heap is empty. Takes O(1) time.
+func (bdh *BoundedHeap[V]) Bottom() V {
+	return bdh.bh.Top().GetValue()
+}
+
+// Returns kept values in descending order of priority without modifying the bounded heap.
+// Takes O(k log k) time where k is number of kept values.
+func (bdh *BoundedHeap[V]) Sorted() []V {
+	values := make([]V, 0, bdh.bh.Len())
+	for i := int64(1); i <= bdh.bh.Len(); i++ {
+		values = append(values, bdh.bh.nodes[i].GetValue())
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return bdh.priorityFunc(values[i], values[j])
+	})
+	return values
+}
+
+// Offers every value kept by other to the bounded heap. other is not modified.
+// Used to merge partial top-k results. Takes O(m log k) time where m is number of values kept by other and k is capacity.
+func (bdh *BoundedHeap[V]) Merge(other *BoundedHeap[V]) {
+	if other == bdh {
+		return
+	}
+	for i := int64(1); i <= other.bh.Len(); i++ {
+		bdh.Offer(other.bh.nodes[i].GetValue())
+	}
+}
+
+// Returns number of values currently kept
+func (bdh *BoundedHeap[V]) Len() int64 {
+	return bdh.bh.Len()
+}
+
+// Returns maximum number of values that can be kept
+func (bdh *BoundedHeap[V]) Capacity() int64 {
+	return bdh.capacity
+}
+
+// Returns priority function where v1 has higher priority than v2 if v1 has lower priority than v2 in priorityFunc
+func lowerPriorityFunc[V any](priorityFunc func(v1, v2 V) bool) func(v1, v2 V) bool {
+	return func(v1, v2 V) bool {
+		return priorityFunc(v2, v1)
+	}
+}
+
+// Reorders values such that values[:k] contains k highest priority values. Takes O(n) expected time where n = len(values)
+func selectHighest[V any](values []V, k int, priorityFunc func(v1, v2 V) bool) {
+	lo, hi := 0, len(values)-1
+	for lo < hi {
+		// median of three as pivot
+		mid := lo + (hi-lo)/2
+		if priorityFunc(values[mid], values[lo]) {
+			values[mid], values[lo] = values[lo], values[mid]
+		}
+		if priorityFunc(values[hi], values[lo]) {
+			values[hi], values[lo] = values[lo], values[hi]
+		}
+		if priorityFunc(values[hi], values[mid]) {
+			values[hi], values[mid] = values[mid], values[hi]
+		}
+		pivot := values[mid]
+
+		// three way partition: [lo, lt) higher priority than pivot, [lt, gt] equal to pivot, (gt, hi] lower priority than pivot
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case priorityFunc(values[i], pivot):
+				values[lt], values[i] = values[i], values[lt]
+				lt++
+				i++
+			case priorityFunc(pivot, values[i]):
+				values[gt], values[i] = values[i], values[gt]
+				gt--
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package priorityqueue_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+func TestBoundedHeapOffer(t *testing.T) {
+	bdh := priorityqueue.NewBoundedHeap[int](func(v1, v2 int) bool { return v1 > v2 }, 3)
+	assertOffer := func(value, expEvicted int, expOk bool) {
+		evicted, ok := bdh.Offer(value)
+		if evicted != expEvicted || ok != expOk {
+			t.Errorf("Offer(%d) = (%d, %v), exp = (%d, %v)", value, evicted, ok, expEvicted, expOk)
+		}
+	}
+	assertOffer(5, 0, false)
+	assertOffer(1, 0, false)
+	assertOffer(7, 0, false)
+	// full; lowest kept value is evicted
+	assertOffer(4, 1, true)
+	// offered value has lowest priority; offered value is rejected
+	assertOffer(2, 2, true)
+	assertOffer(4, 4, true)
+	if bottom := bdh.Bottom(); bottom != 4 {
+		t.Errorf("Bottom() = %d, exp = 4", bottom)
+	}
+	assertSorted(t, bdh.Sorted(), []int{7, 5, 4})
+	if bdh.Len() != 3 || bdh.Capacity() != 3 {
+		t.Errorf("Len() = %d, Capacity() = %d, exp = 3 and 3", bdh.Len(), bdh.Capacity())
+	}
+}
+
+func TestBoundedHeapStream(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = r.Intn(500)
+	}
+	expTopK := append([]int{}, values...)
+	sort.Sort(sort.Reverse(sort.IntSlice(expTopK)))
+	expTopK = expTopK[:100]
+
+	// initialize with whole slice
+	bdh := priorityqueue.InitBoundedHeap[int](func(v1, v2 int) bool { return v1 > v2 }, 100, values)
+	assertSorted(t, bdh.Sorted(), expTopK)
+
+	// initialize with fewer values than capacity and stream the rest
+	bdh = priorityqueue.InitBoundedHeap[int](func(v1, v2 int) bool { return v1 > v2 }, 100, values[:50])
+	for _, v := range values[50:] {
+		bdh.Offer(v)
+	}
+	assertSorted(t, bdh.Sorted(), expTopK)
+
+	// merge partial results of workers
+	merged := priorityqueue.NewBoundedHeap[int](func(v1, v2 int) bool { return v1 > v2 }, 100)
+	for worker := 0; worker < 4; worker++ {
+		partial := priorityqueue.InitBoundedHeap[int](func(v1, v2 int) bool { return v1 > v2 }, 100, values[worker*250:(worker+1)*250])
+		merged.Merge(partial)
+	}
+	assertSorted(t, merged.Sorted(), expTopK)
+}
+
+func assertSorted(t *testing.T, found, exp []int) {
+	t.Helper()
+	if len(found) != len(exp) {
+		t.Fatalf("len(found) = %d, len(exp) = %d", len(found), len(exp))
+	}
+	for i := range exp {
+		if found[i] != exp[i] {
+			t.Fatalf("found[%d] = %d, exp[%d] = %d", i, found[i], i, exp[i])
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package priorityqueue
+
+import "time"
+
+// Source of current time and timers used by time based containers. Can be replaced by a fake clock in tests.
+type Clock interface {
+	// Returns current time
+	Now() time.Time
+	// Returns timer that delivers current time on its channel after at least duration d
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer created by Clock
+type Timer interface {
+	// Returns channel on which the time is delivered when timer fires
+	C() <-chan time.Time
+	// Prevents the timer from firing. Returns false if the timer has already fired or been stopped.
+	Stop() bool
+}
+
+// Returns Clock backed by time package
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (st systemTimer) C() <-chan time.Time {
+	return st.timer.C
+}
+
+func (st systemTimer) Stop() bool {
+	return st.timer.Stop()
+}
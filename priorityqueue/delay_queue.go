@@ -0,0 +1,165 @@
+package priorityqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type delayedValue[V any] struct {
+	value V
+	at    time.Time
+}
+
+// Handle to a scheduled value. Used to Reschedule or Cancel the value later.
+type DelayQueueNode[V any] struct {
+	node *BinaryHeapNode[delayedValue[V]]
+}
+
+// Returns node's value
+func (dqn *DelayQueueNode[V]) GetValue() V {
+	return dqn.node.value.value
+}
+
+// Returns time at which node's value becomes available. Reading it concurrently with Reschedule is not safe.
+func (dqn *DelayQueueNode[V]) GetDeadline() time.Time {
+	return dqn.node.value.at
+}
+
+// Goroutine safe queue where every value becomes available at its deadline.
+// Values are kept in a stable BinaryHeap ordered by deadline, so values with same deadline are served in the order they are scheduled.
+type DelayQueue[V any] struct {
+	mu    sync.Mutex
+	bh    *BinaryHeap[delayedValue[V]]
+	clock Clock
+	// closed and replaced whenever queue changes to wake up waiting goroutines
+	changed chan struct{}
+}
+
+// Returns instance of DelayQueue using system clock
+func NewDelayQueue[V any]() *DelayQueue[V] {
+	return NewDelayQueueWithClock[V](SystemClock())
+}
+
+// Returns instance of DelayQueue using given clock
+func NewDelayQueueWithClock[V any](clock Clock) *DelayQueue[V] {
+	return &DelayQueue[V]{
+		bh: NewStableBinaryHeap[delayedValue[V]](func(v1, v2 delayedValue[V]) bool {
+			return v1.at.Before(v2.at)
+		}),
+		clock:   clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// Schedules value to become available at given time and returns node's pointer to it.
+// Takes O(log n) time where n is number of values in the queue.
+func (dq *DelayQueue[V]) Schedule(value V, at time.Time) *DelayQueueNode[V] {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	node := dq.bh.Push(delayedValue[V]{value: value, at: at})
+	dq.notify()
+	return &DelayQueueNode[V]{node: node}
+}
+
+// Changes deadline of node's value. Returns false if value is already taken or cancelled.
+// Takes O(log n) time where n is number of values in the queue.
+func (dq *DelayQueue[V]) Reschedule(node *DelayQueueNode[V], at time.Time) bool {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if !dq.bh.Contains(node.node) {
+		return false
+	}
+	dq.bh.Update(node.node, delayedValue[V]{value: node.node.value.value, at: at})
+	dq.notify()
+	return true
+}
+
+// Removes node's value from the queue. Returns false if value is already taken or cancelled.
+// Takes O(log n) time where n is number of values in the queue.
+func (dq *DelayQueue[V]) Cancel(node *DelayQueueNode[V]) bool {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if !dq.bh.Contains(node.node) {
+		return false
+	}
+	dq.bh.Remove(node.node)
+	dq.notify()
+	return true
+}
+
+// Removes value with earliest deadline and returns it. Blocks until the deadline is reached.
+// Returns ctx.Err() if ctx is done before a value becomes available.
+func (dq *DelayQueue[V]) Take(ctx context.Context) (_ V, _ error) {
+	for {
+		dq.mu.Lock()
+		var timer Timer
+		var fired <-chan time.Time
+		if dq.bh.Len() > 0 {
+			top := dq.bh.Top()
+			delay := top.value.at.Sub(dq.clock.Now())
+			if delay <= 0 {
+				dq.bh.Remove(top)
+				dq.notify()
+				dq.mu.Unlock()
+				return top.value.value, nil
+			}
+			timer = dq.clock.NewTimer(delay)
+			fired = timer.C()
+		}
+		changed := dq.changed
+		dq.mu.Unlock()
+
+		select {
+		case <-fired:
+		case <-changed:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return *new(V), err
+		}
+	}
+}
+
+// Removes value with earliest deadline without blocking and returns it.
+// Returns (zeroValue, false) if queue is empty or the earliest deadline is not reached yet.
+func (dq *DelayQueue[V]) Poll() (_ V, _ bool) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.bh.Len() == 0 {
+		return
+	}
+	top := dq.bh.Top()
+	if dq.clock.Now().Before(top.value.at) {
+		return
+	}
+	dq.bh.Remove(top)
+	dq.notify()
+	return top.value.value, true
+}
+
+// Returns earliest deadline among scheduled values. Returns (zeroValue, false) if queue is empty.
+func (dq *DelayQueue[V]) NextDeadline() (_ time.Time, _ bool) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.bh.Len() == 0 {
+		return
+	}
+	return dq.bh.Top().value.at, true
+}
+
+// Returns number of scheduled values, including values whose deadline is not reached yet
+func (dq *DelayQueue[V]) Len() int64 {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return dq.bh.Len()
+}
+
+// wakes up all waiting goroutines. Must be called with mu held
+func (dq *DelayQueue[V]) notify() {
+	close(dq.changed)
+	dq.changed = make(chan struct{})
+}
@@ -0,0 +1,173 @@
+package priorityqueue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+// Clock whose time moves only on Advance
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	// receives a value whenever a timer is created
+	created chan struct{}
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		created: make(chan struct{}, 64),
+	}
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+func (fc *fakeClock) NewTimer(d time.Duration) priorityqueue.Timer {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	ft := &fakeTimer{clock: fc, at: fc.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		ft.c <- fc.now
+	} else {
+		fc.timers = append(fc.timers, ft)
+	}
+	select {
+	case fc.created <- struct{}{}:
+	default:
+	}
+	return ft
+}
+
+// Moves time forward by d and fires due timers
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = fc.now.Add(d)
+	pending := fc.timers[:0]
+	for _, ft := range fc.timers {
+		if ft.at.After(fc.now) {
+			pending = append(pending, ft)
+			continue
+		}
+		ft.c <- fc.now
+	}
+	fc.timers = pending
+}
+
+func (ft *fakeTimer) C() <-chan time.Time {
+	return ft.c
+}
+
+func (ft *fakeTimer) Stop() bool {
+	fc := ft.clock
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for i, t := range fc.timers {
+		if t == ft {
+			fc.timers = append(fc.timers[:i], fc.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func TestDelayQueuePoll(t *testing.T) {
+	clock := newFakeClock()
+	dq := priorityqueue.NewDelayQueueWithClock[string](clock)
+	start := clock.Now()
+	dq.Schedule("c", start.Add(3*time.Second))
+	a := dq.Schedule("a", start.Add(time.Second))
+	b := dq.Schedule("b", start.Add(2*time.Second))
+	dq.Schedule("d", start.Add(3*time.Second))
+
+	if v, ok := dq.Poll(); ok {
+		t.Errorf("Poll() = (%s, true) before any deadline; exp ok = false", v)
+	}
+	if at, _ := dq.NextDeadline(); !at.Equal(a.GetDeadline()) {
+		t.Errorf("NextDeadline() = %v, exp = %v", at, a.GetDeadline())
+	}
+	if !dq.Reschedule(a, start.Add(5*time.Second)) {
+		t.Errorf("Reschedule() = false; exp = true")
+	}
+	if !dq.Cancel(b) {
+		t.Errorf("Cancel() = false; exp = true")
+	}
+	if dq.Cancel(b) || dq.Reschedule(b, start) {
+		t.Errorf("Cancel() or Reschedule() of cancelled node; exp = false")
+	}
+
+	clock.Advance(3 * time.Second)
+	for _, exp := range []string{"c", "d"} {
+		if v, ok := dq.Poll(); v != exp || !ok {
+			t.Errorf("Poll() = (%s, %v), exp = (%s, true)", v, ok, exp)
+		}
+	}
+	if _, ok := dq.Poll(); ok {
+		t.Errorf("Poll() before deadline of a; exp ok = false")
+	}
+	clock.Advance(2 * time.Second)
+	if v, ok := dq.Poll(); v != "a" || !ok {
+		t.Errorf("Poll() = (%s, %v), exp = (a, true)", v, ok)
+	}
+	if dq.Len() != 0 {
+		t.Errorf("Len() = %d, exp = 0", dq.Len())
+	}
+}
+
+func TestDelayQueueTake(t *testing.T) {
+	clock := newFakeClock()
+	dq := priorityqueue.NewDelayQueueWithClock[int](clock)
+	start := clock.Now()
+	dq.Schedule(2, start.Add(2*time.Second))
+
+	taken := make(chan int)
+	go func() {
+		for i := 0; i < 2; i++ {
+			v, err := dq.Take(context.Background())
+			if err != nil {
+				t.Errorf("Take() err = %v", err)
+			}
+			taken <- v
+		}
+	}()
+
+	<-clock.created
+	// earlier deadline wakes up Take waiting for later deadline
+	dq.Schedule(1, start.Add(time.Second))
+	<-clock.created
+	clock.Advance(time.Second)
+	if v := <-taken; v != 1 {
+		t.Errorf("Take() = %d, exp = 1", v)
+	}
+	<-clock.created
+	clock.Advance(time.Second)
+	if v := <-taken; v != 2 {
+		t.Errorf("Take() = %d, exp = 2", v)
+	}
+
+	dq.Schedule(3, start.Add(time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-clock.created
+		cancel()
+	}()
+	if _, err := dq.Take(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Take() err = %v, exp = %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,269 @@
+package priorityqueue
+
+// Fibonacci heap node
+type FibonacciHeapNode[V any] struct {
+	// left and right link node to its siblings (or other roots) in a circular list
+	parent, child, left, right *FibonacciHeapNode[V]
+	degree                     int64
+	marked                     bool
+	removed                    bool
+	value                      V
+}
+
+// Returns node's value
+func (fhn *FibonacciHeapNode[V]) GetValue() V {
+	return fhn.value
+}
+
+// Implements priorityqueue with Push, Pop, Top, Update, Remove and Meld operations.
+// Push, Meld and Update towards higher priority take O(1) amortized time. Pop and Remove take O(log n) amortized time where n is number of values in the queue.
+type FibonacciHeap[V any] struct {
+	top          *FibonacciHeapNode[V]
+	priorityFunc func(v1, v2 V) bool
+	length       int64
+}
+
+// Returns instance of FibonacciHeap.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+func NewFibonacciHeap[V any](priorityFunc func(v1, v2 V) bool) *FibonacciHeap[V] {
+	return &FibonacciHeap[V]{
+		priorityFunc: priorityFunc,
+		length:       0,
+	}
+}
+
+// Insert value into the fibonacci heap and returns node's pointer to pushed value. Takes O(1) time.
+func (fh *FibonacciHeap[V]) Push(value V) *FibonacciHeapNode[V] {
+	newHeapNode := &FibonacciHeapNode[V]{
+		value: value,
+	}
+	fh.insert(newHeapNode)
+	fh.length++
+	return newHeapNode
+}
+
+// Returns node's pointer of highest priority value. Panics if fibonacci heap is empty. Takes O(1) time.
+func (fh *FibonacciHeap[V]) Top() *FibonacciHeapNode[V] {
+	if fh.top == nil {
+		panic("fibonacci heap is empty")
+	}
+	return fh.top
+}
+
+// Remove highest priority value and returns it. Panics if fibonacci heap is empty. Takes O(log n) amortized time where n is number of values in the queue.
+func (fh *FibonacciHeap[V]) Pop() V {
+	return fh.Remove(fh.Top())
+}
+
+// Deletes node in fibonacci heap. Has no effect if node is already removed. Takes O(log n) amortized time where n is number of values in the queue.
+func (fh *FibonacciHeap[V]) Remove(node *FibonacciHeapNode[V]) V {
+	if node.removed {
+		return node.value
+	}
+	if parent := node.parent; parent != nil {
+		fh.cut(node, parent)
+		fh.cascadingCut(parent)
+	}
+	// node is a root now; treat it as highest priority value and extract it
+	fh.top = node
+	fh.extractTop()
+	fh.length--
+	return node.value
+}
+
+// Update node's value to newValue. Has no effect if node is already removed.
+// Takes O(1) amortized time if newValue does not have lower priority than node's value. Otherwise, takes O(log n) amortized time where n is number of values in the queue.
+func (fh *FibonacciHeap[V]) Update(node *FibonacciHeapNode[V], newValue V) {
+	if node.removed {
+		return
+	}
+	oldValue := node.value
+	if fh.priorityFunc(oldValue, newValue) {
+		// priority decreased; reinsert node with new value
+		fh.Remove(node)
+		node.value = newValue
+		node.removed = false
+		fh.insert(node)
+		fh.length++
+		return
+	}
+	node.value = newValue
+	if parent := node.parent; parent != nil && fh.priorityFunc(newValue, parent.value) {
+		fh.cut(node, parent)
+		fh.cascadingCut(parent)
+	}
+	if fh.priorityFunc(newValue, fh.top.value) {
+		fh.top = node
+	}
+}
+
+// Moves all values of other into the fibonacci heap, leaving other empty. Nodes of other remain valid and now belong to the fibonacci heap.
+// Takes O(1) time.
+func (fh *FibonacciHeap[V]) Meld(other *FibonacciHeap[V]) {
+	if other == fh || other.top == nil {
+		return
+	}
+	if fh.top == nil {
+		fh.top = other.top
+	} else {
+		// splice root list of other to the right of top
+		a, b := fh.top, other.top
+		aRight, bLeft := a.right, b.left
+		a.right = b
+		b.left = a
+		bLeft.right = aRight
+		aRight.left = bLeft
+		if fh.priorityFunc(b.value, a.value) {
+			fh.top = b
+		}
+	}
+	fh.length += other.length
+	other.top = nil
+	other.length = 0
+}
+
+// Returns number of values currently in the queue
+func (fh *FibonacciHeap[V]) Len() int64 {
+	return fh.length
+}
+
+// adds detached node to root list and updates top
+func (fh *FibonacciHeap[V]) insert(node *FibonacciHeapNode[V]) {
+	node.parent = nil
+	node.marked = false
+	if fh.top == nil {
+		node.left = node
+		node.right = node
+		fh.top = node
+		return
+	}
+	fh.addToRootList(node)
+	if fh.priorityFunc(node.value, fh.top.value) {
+		fh.top = node
+	}
+}
+
+// adds node to the right of top. top must not be nil
+func (fh *FibonacciHeap[V]) addToRootList(node *FibonacciHeapNode[V]) {
+	node.left = fh.top
+	node.right = fh.top.right
+	fh.top.right.left = node
+	fh.top.right = node
+}
+
+// removes top from root list, moves its children to root list and consolidates the roots
+func (fh *FibonacciHeap[V]) extractTop() {
+	z := fh.top
+	if child := z.child; child != nil {
+		children := make([]*FibonacciHeapNode[V], 0, z.degree)
+		for c := child; ; {
+			children = append(children, c)
+			c = c.right
+			if c == child {
+				break
+			}
+		}
+		for _, c := range children {
+			c.parent = nil
+			c.marked = false
+			fh.addToRootList(c)
+		}
+	}
+	if z.right == z {
+		fh.top = nil
+	} else {
+		z.left.right = z.right
+		z.right.left = z.left
+		fh.top = z.right
+		fh.consolidate()
+	}
+	z.parent = nil
+	z.child = nil
+	z.left = nil
+	z.right = nil
+	z.degree = 0
+	z.marked = false
+	z.removed = true
+}
+
+// links roots of same degree until every root has distinct degree and recomputes top
+func (fh *FibonacciHeap[V]) consolidate() {
+	roots := make([]*FibonacciHeapNode[V], 0)
+	for r := fh.top; ; {
+		roots = append(roots, r)
+		r = r.right
+		if r == fh.top {
+			break
+		}
+	}
+	byDegree := make([]*FibonacciHeapNode[V], 0, 64)
+	for _, x := range roots {
+		x.left = x
+		x.right = x
+		d := x.degree
+		for d < int64(len(byDegree)) && byDegree[d] != nil {
+			y := byDegree[d]
+			if fh.priorityFunc(y.value, x.value) {
+				x, y = y, x
+			}
+			fh.link(y, x)
+			byDegree[d] = nil
+			d++
+		}
+		for int64(len(byDegree)) <= d {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[d] = x
+	}
+	fh.top = nil
+	for _, x := range byDegree {
+		if x != nil {
+			fh.insert(x)
+		}
+	}
+}
+
+// makes root y a child of root x
+func (fh *FibonacciHeap[V]) link(y, x *FibonacciHeapNode[V]) {
+	y.parent = x
+	y.marked = false
+	if x.child == nil {
+		y.left = y
+		y.right = y
+		x.child = y
+	} else {
+		y.left = x.child
+		y.right = x.child.right
+		x.child.right.left = y
+		x.child.right = y
+	}
+	x.degree++
+}
+
+// cuts node from its parent and moves it to root list
+func (fh *FibonacciHeap[V]) cut(node, parent *FibonacciHeapNode[V]) {
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		node.left.right = node.right
+		node.right.left = node.left
+	}
+	parent.degree--
+	node.parent = nil
+	node.marked = false
+	fh.addToRootList(node)
+}
+
+func (fh *FibonacciHeap[V]) cascadingCut(node *FibonacciHeapNode[V]) {
+	for parent := node.parent; parent != nil; parent = node.parent {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+		fh.cut(node, parent)
+		node = parent
+	}
+}
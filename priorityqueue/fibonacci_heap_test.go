@@ -0,0 +1,36 @@
+package priorityqueue_test
+
+import (
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+	"github.com/storybehind/gocontainer/priorityqueue/priorityqueuetest"
+)
+
+func TestFibonacciHeap(t *testing.T) {
+	priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, *priorityqueue.FibonacciHeapNode[int]] {
+		return priorityqueue.NewFibonacciHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	})
+}
+
+func TestFibonacciHeapMeld(t *testing.T) {
+	fh1 := priorityqueue.NewFibonacciHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	fh2 := priorityqueue.NewFibonacciHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	fh1.Push(5)
+	fh1.Push(3)
+	node8 := fh2.Push(8)
+	fh2.Push(1)
+	fh2.Push(4)
+
+	fh1.Meld(fh2)
+	if fh1.Len() != 5 || fh2.Len() != 0 {
+		t.Errorf("after meld; fh1.Len() = %d, fh2.Len() = %d, want 5 and 0", fh1.Len(), fh2.Len())
+	}
+	// nodes of melded heap remain valid
+	fh1.Update(node8, 2)
+	for _, expValue := range []int{1, 2, 3, 4, 5} {
+		if v := fh1.Pop(); v != expValue {
+			t.Errorf("fh1.Pop() = %d, expValue = %d", v, expValue)
+		}
+	}
+}
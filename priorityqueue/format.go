@@ -0,0 +1,43 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/storybehind/gocontainer/internal/fmtutil"
+)
+
+// Returns values in descending order of priority like [v1 v2 v3], truncated after fmtutil.DefaultLimit values
+func (bh *BinaryHeap[V]) String() string {
+	return fmt.Sprint(bh)
+}
+
+// Implements fmt.Formatter. %v and %s print values in descending order of priority like [v1 v2 v3], visiting only printed values.
+// %+v prints arity, stability and values in heap array order, where children of value at index i (starting from 0) are at indices arity*i+1 to arity*i+arity.
+// Precision limits number of printed values, for example %.10v. By default, at most fmtutil.DefaultLimit values are printed.
+func (bh *BinaryHeap[V]) Format(state fmt.State, verb rune) {
+	if verb != 'v' && verb != 's' {
+		fmtutil.WriteBadVerb(state, verb, bh)
+		return
+	}
+	limit := fmtutil.Limit(state)
+	if state.Flag('+') {
+		fmt.Fprintf(state, "BinaryHeap{arity:%d stable:%v nodes:", bh.arity, bh.stable)
+		index := int64(1)
+		fmtutil.WriteSeq(state, "[", "]}", bh.length, limit, func(w io.Writer) bool {
+			fmt.Fprint(w, bh.nodes[index].value)
+			index++
+			return true
+		})
+		return
+	}
+	iterator := bh.SortedIter()
+	fmtutil.WriteSeq(state, "[", "]", bh.length, limit, func(w io.Writer) bool {
+		value, ok := iterator.Key()
+		if ok {
+			fmt.Fprint(w, value)
+			iterator.Next()
+		}
+		return ok
+	})
+}
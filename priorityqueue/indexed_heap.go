@@ -0,0 +1,91 @@
+package priorityqueue
+
+type indexedValue[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Implements priorityqueue where every value is identified by a unique key.
+// Maintains key to node mapping internally, so values can be updated or removed by key.
+type IndexedHeap[K comparable, V any] struct {
+	bh    *BinaryHeap[indexedValue[K, V]]
+	nodes map[K]*BinaryHeapNode[indexedValue[K, V]]
+}
+
+// Returns instance of IndexedHeap.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+func NewIndexedHeap[K comparable, V any](priorityFunc func(v1, v2 V) bool) *IndexedHeap[K, V] {
+	return &IndexedHeap[K, V]{
+		bh: NewBinaryHeap[indexedValue[K, V]](func(iv1, iv2 indexedValue[K, V]) bool {
+			return priorityFunc(iv1.value, iv2.value)
+		}),
+		nodes: make(map[K]*BinaryHeapNode[indexedValue[K, V]]),
+	}
+}
+
+// Inserts key with given value. If key is already present, updates its value.
+// Returns previous value and true if key was present. Otherwise, returns (zeroValue, false)
+// Takes O(log n) time where n is number of values in the queue.
+func (ih *IndexedHeap[K, V]) PushOrUpdate(key K, value V) (_ V, _ bool) {
+	newValue := indexedValue[K, V]{
+		key:   key,
+		value: value,
+	}
+	if node, has := ih.nodes[key]; has {
+		prevValue := node.GetValue().value
+		ih.bh.Update(node, newValue)
+		return prevValue, true
+	}
+	ih.nodes[key] = ih.bh.Push(newValue)
+	return
+}
+
+// Returns value of the key, or (zeroValue, false) if key is not present. Takes O(1) time.
+func (ih *IndexedHeap[K, V]) Get(key K) (_ V, _ bool) {
+	if node, has := ih.nodes[key]; has {
+		return node.GetValue().value, true
+	}
+	return
+}
+
+// Returns true if key is present. Takes O(1) time.
+func (ih *IndexedHeap[K, V]) Contains(key K) bool {
+	_, has := ih.nodes[key]
+	return has
+}
+
+// Deletes the key and returns its value, and the second return value is true.
+// If key is not present, returns (zeroValue, false). Takes O(log n) time where n is number of values in the queue.
+func (ih *IndexedHeap[K, V]) Remove(key K) (_ V, _ bool) {
+	node, has := ih.nodes[key]
+	if !has {
+		return
+	}
+	delete(ih.nodes, key)
+	return ih.bh.Remove(node).value, true
+}
+
+// Returns key and value with highest priority. Panics if indexed heap is empty. Takes O(1) time.
+func (ih *IndexedHeap[K, V]) Top() (K, V) {
+	top := ih.bh.Top().GetValue()
+	return top.key, top.value
+}
+
+// Removes highest priority value and returns it. Panics if indexed heap is empty. Takes O(log n) time where n is number of values in the queue.
+func (ih *IndexedHeap[K, V]) Pop() V {
+	_, value := ih.PopWithKey()
+	return value
+}
+
+// Removes highest priority value and returns it along with its key. Panics if indexed heap is empty.
+// Takes O(log n) time where n is number of values in the queue.
+func (ih *IndexedHeap[K, V]) PopWithKey() (K, V) {
+	top := ih.bh.Pop()
+	delete(ih.nodes, top.key)
+	return top.key, top.value
+}
+
+// Returns number of values currently in the queue
+func (ih *IndexedHeap[K, V]) Len() int64 {
+	return ih.bh.Len()
+}
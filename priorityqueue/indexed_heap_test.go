@@ -0,0 +1,61 @@
+package priorityqueue_test
+
+import (
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	ih := priorityqueue.NewIndexedHeap[string, int](func(v1, v2 int) bool { return v1 < v2 })
+	assertPushOrUpdate := func(key string, value, expPrevValue int, expHas bool) {
+		prevValue, has := ih.PushOrUpdate(key, value)
+		if prevValue != expPrevValue || has != expHas {
+			t.Errorf("PushOrUpdate(%q, %d) = (%d, %v), exp = (%d, %v)", key, value, prevValue, has, expPrevValue, expHas)
+		}
+	}
+	assertGet := func(key string, expValue int, expHas bool) {
+		value, has := ih.Get(key)
+		if value != expValue || has != expHas || ih.Contains(key) != expHas {
+			t.Errorf("Get(%q) = (%d, %v), Contains(%q) = %v, exp = (%d, %v)", key, value, has, key, ih.Contains(key), expValue, expHas)
+		}
+	}
+	assertPopWithKey := func(expKey string, expValue int) {
+		key, value := ih.PopWithKey()
+		if key != expKey || value != expValue {
+			t.Errorf("PopWithKey() = (%q, %d), exp = (%q, %d)", key, value, expKey, expValue)
+		}
+	}
+
+	assertPushOrUpdate("a", 5, 0, false)
+	assertPushOrUpdate("b", 2, 0, false)
+	assertPushOrUpdate("c", 7, 0, false)
+	assertPushOrUpdate("d", 4, 0, false)
+	assertGet("a", 5, true)
+	assertGet("e", 0, false)
+
+	// decrease key
+	assertPushOrUpdate("c", 1, 7, true)
+	if key, value := ih.Top(); key != "c" || value != 1 {
+		t.Errorf("Top() = (%q, %d), exp = (\"c\", 1)", key, value)
+	}
+
+	if value, has := ih.Remove("b"); value != 2 || !has {
+		t.Errorf("Remove(\"b\") = (%d, %v), exp = (2, true)", value, has)
+	}
+	if _, has := ih.Remove("b"); has {
+		t.Errorf("Remove(\"b\") on removed key; exp has = false")
+	}
+	assertGet("b", 0, false)
+
+	assertPopWithKey("c", 1)
+	assertGet("c", 0, false)
+	assertPopWithKey("d", 4)
+	if ih.Len() != 1 {
+		t.Errorf("ih.Len() = %d, exp = 1", ih.Len())
+	}
+	if value := ih.Pop(); value != 5 {
+		t.Errorf("Pop() = %d, exp = 5", value)
+	}
+	assertGet("a", 0, false)
+}
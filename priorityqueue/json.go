@@ -0,0 +1,28 @@
+package priorityqueue
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Encodes values of the binary heap as JSON array in descending order of priority. Implements json.Marshaler.
+// Takes O(n log n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bh.Peek(int(bh.length)))
+}
+
+// Decodes JSON array of values into the binary heap, replacing its contents. Nodes of previous values are removed.
+// In stable heap, values of equal priority are served in array order.
+// Implements json.Unmarshaler. Binary heap must be created by a constructor since priority function cannot be decoded.
+// Takes O(n) time after decoding where n is number of decoded values.
+func (bh *BinaryHeap[V]) UnmarshalJSON(data []byte) error {
+	if bh.priorityFunc == nil {
+		return errors.New("priorityqueue: UnmarshalJSON on binary heap not created by constructor")
+	}
+	var values []V
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	bh.replaceAll(values)
+	return nil
+}
@@ -0,0 +1,230 @@
+package priorityqueue
+
+import "math/bits"
+
+// Min-max heap node
+type MinMaxHeapNode[V any] struct {
+	index      int64
+	minMaxHeap *MinMaxHeap[V]
+	value      V
+}
+
+// Returns node's value
+func (mmhn *MinMaxHeapNode[V]) GetValue() V {
+	return mmhn.value
+}
+
+// Implements double ended priorityqueue with Push, Top, Bottom, PopTop, PopBottom, Update and Remove operations.
+// Nodes in even levels (top levels) have higher priority than their descendants and nodes in odd levels (bottom levels) have lower priority than their descendants.
+type MinMaxHeap[V any] struct {
+	nodes        []*MinMaxHeapNode[V]
+	priorityFunc func(v1, v2 V) bool
+	length       int64
+}
+
+// Returns instance of MinMaxHeap.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+func NewMinMaxHeap[V any](priorityFunc func(v1, v2 V) bool) *MinMaxHeap[V] {
+	return &MinMaxHeap[V]{
+		nodes:        make([]*MinMaxHeapNode[V], 1),
+		priorityFunc: priorityFunc,
+		length:       0,
+	}
+}
+
+// Returns instance of MinMaxHeap.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// Takes O(n) time where n = len(initValues)
+func InitMinMaxHeap[V any](priorityFunc func(v1, v2 V) bool, initValues []V) *MinMaxHeap[V] {
+	n := len(initValues)
+	mmh := &MinMaxHeap[V]{
+		nodes:        make([]*MinMaxHeapNode[V], n+1),
+		priorityFunc: priorityFunc,
+		length:       int64(n),
+	}
+	for i, v := range initValues {
+		mmh.nodes[i+1] = &MinMaxHeapNode[V]{
+			index:      int64(i + 1),
+			minMaxHeap: mmh,
+			value:      v,
+		}
+	}
+	for i := int64(n / 2); i >= 1; i-- {
+		mmh.siftDown(i, isTopLevel(i))
+	}
+	return mmh
+}
+
+// Insert value into the min-max heap and returns node's pointer to pushed value.
+// Takes O(log n) time where n is number of values in the queue.
+func (mmh *MinMaxHeap[V]) Push(value V) *MinMaxHeapNode[V] {
+	newHeapNode := &MinMaxHeapNode[V]{
+		index:      int64(len(mmh.nodes)),
+		minMaxHeap: mmh,
+		value:      value,
+	}
+	mmh.nodes = append(mmh.nodes, newHeapNode)
+	mmh.length++
+	mmh.sift(newHeapNode.index)
+	return newHeapNode
+}
+
+// Returns node's pointer of highest priority value. Panics if min-max heap is empty. Takes O(1) time.
+func (mmh *MinMaxHeap[V]) Top() *MinMaxHeapNode[V] {
+	if mmh.length == 0 {
+		panic("min-max heap is empty")
+	}
+	return mmh.nodes[1]
+}
+
+// Returns node's pointer of lowest priority value. Panics if min-max heap is empty. Takes O(1) time.
+func (mmh *MinMaxHeap[V]) Bottom() *MinMaxHeapNode[V] {
+	if mmh.length == 0 {
+		panic("min-max heap is empty")
+	}
+	return mmh.nodes[mmh.bottomIndex()]
+}
+
+// Remove highest priority value and returns it. Same as PopTop. Panics if min-max heap is empty.
+// Takes O(log n) time where n is number of values in the queue.
+func (mmh *MinMaxHeap[V]) Pop() V {
+	return mmh.PopTop()
+}
+
+// Remove highest priority value and returns it. Panics if min-max heap is empty. Takes O(log n) time where n is number of values in the queue.
+func (mmh *MinMaxHeap[V]) PopTop() V {
+	return mmh.Remove(mmh.Top())
+}
+
+// Remove lowest priority value and returns it. Panics if min-max heap is empty. Takes O(log n) time where n is number of values in the queue.
+func (mmh *MinMaxHeap[V]) PopBottom() V {
+	return mmh.Remove(mmh.Bottom())
+}
+
+// Deletes node in min-max heap. Has no effect if node is already removed. Takes O(log n) time where n is number of values in the queue.
+func (mmh *MinMaxHeap[V]) Remove(node *MinMaxHeapNode[V]) V {
+	if node.minMaxHeap == nil {
+		return node.value
+	}
+	nodeIndex := node.index
+	if nodeIndex != mmh.length {
+		mmh.swapNodes(nodeIndex, mmh.length)
+	}
+	mmh.nodes[mmh.length] = nil
+	mmh.nodes = mmh.nodes[:mmh.length]
+	mmh.length--
+
+	node.minMaxHeap = nil
+	node.index = 0
+
+	if nodeIndex <= mmh.length {
+		mmh.sift(nodeIndex)
+	}
+	return node.value
+}
+
+// Update node's value to newValue. Has no effect if node is already removed. Takes O(log n) time where n is number of values in the queue.
+func (mmh *MinMaxHeap[V]) Update(node *MinMaxHeapNode[V], newValue V) {
+	if node.minMaxHeap == nil {
+		return
+	}
+	node.value = newValue
+	mmh.sift(node.index)
+}
+
+// Returns number of values currently in the queue
+func (mmh *MinMaxHeap[V]) Len() int64 {
+	return mmh.length
+}
+
+// Returns true if node at nodeIndex lies in even level (top level)
+func isTopLevel(nodeIndex int64) bool {
+	return bits.Len64(uint64(nodeIndex))%2 == 1
+}
+
+func (mmh *MinMaxHeap[V]) bottomIndex() int64 {
+	switch mmh.length {
+	case 1:
+		return 1
+	case 2:
+		return 2
+	}
+	if mmh.priorityFunc(mmh.nodes[2].value, mmh.nodes[3].value) {
+		return 3
+	}
+	return 2
+}
+
+// Returns true if value at id1 should be placed above value at id2 in a top level (or bottom level if top is false)
+func (mmh *MinMaxHeap[V]) before(id1, id2 int64, top bool) bool {
+	if top {
+		return mmh.priorityFunc(mmh.nodes[id1].value, mmh.nodes[id2].value)
+	}
+	return mmh.priorityFunc(mmh.nodes[id2].value, mmh.nodes[id1].value)
+}
+
+// restores heap property for value placed at nodeIndex, which can violate it with respect to both ancestors and descendants
+func (mmh *MinMaxHeap[V]) sift(nodeIndex int64) {
+	top := isTopLevel(nodeIndex)
+	parentIndex := nodeIndex >> 1
+	if parentIndex == 0 {
+		mmh.siftDown(nodeIndex, top)
+		return
+	}
+	if mmh.before(nodeIndex, parentIndex, !top) {
+		// value belongs to parent's levels. Parent's value is placed at nodeIndex and has to move down
+		mmh.swapNodes(nodeIndex, parentIndex)
+		mmh.siftUp(parentIndex, !top)
+		mmh.siftDown(nodeIndex, top)
+		return
+	}
+	grandParentIndex := nodeIndex >> 2
+	if grandParentIndex > 0 && mmh.before(nodeIndex, grandParentIndex, top) {
+		mmh.siftUp(nodeIndex, top)
+		return
+	}
+	mmh.siftDown(nodeIndex, top)
+}
+
+// moves value at nodeIndex up through grandparents of same level type
+func (mmh *MinMaxHeap[V]) siftUp(nodeIndex int64, top bool) {
+	for grandParentIndex := nodeIndex >> 2; grandParentIndex > 0 && mmh.before(nodeIndex, grandParentIndex, top); grandParentIndex = nodeIndex >> 2 {
+		mmh.swapNodes(nodeIndex, grandParentIndex)
+		nodeIndex = grandParentIndex
+	}
+}
+
+// moves value at nodeIndex down through children and grandchildren
+func (mmh *MinMaxHeap[V]) siftDown(nodeIndex int64, top bool) {
+	for {
+		childIndex := nodeIndex << 1
+		if childIndex > mmh.length {
+			return
+		}
+		priorIndex := childIndex
+		candidates := [...]int64{childIndex + 1, childIndex << 1, (childIndex << 1) + 1, (childIndex << 1) + 2, (childIndex << 1) + 3}
+		for _, candidate := range candidates {
+			if candidate <= mmh.length && mmh.before(candidate, priorIndex, top) {
+				priorIndex = candidate
+			}
+		}
+		if !mmh.before(priorIndex, nodeIndex, top) {
+			return
+		}
+		mmh.swapNodes(priorIndex, nodeIndex)
+		if priorIndex < childIndex<<1 {
+			// child lies in other level type and has no descendants to compare with
+			return
+		}
+		if parentIndex := priorIndex >> 1; mmh.before(priorIndex, parentIndex, !top) {
+			mmh.swapNodes(priorIndex, parentIndex)
+		}
+		nodeIndex = priorIndex
+	}
+}
+
+func (mmh *MinMaxHeap[V]) swapNodes(id1, id2 int64) {
+	mmh.nodes[id1], mmh.nodes[id2] = mmh.nodes[id2], mmh.nodes[id1]
+	mmh.nodes[id1].index = id1
+	mmh.nodes[id2].index = id2
+}
@@ -0,0 +1,76 @@
+package priorityqueue_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+	"github.com/storybehind/gocontainer/priorityqueue/priorityqueuetest"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, *priorityqueue.MinMaxHeapNode[int]] {
+		return priorityqueue.NewMinMaxHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	})
+}
+
+func TestMinMaxHeapBottom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	initValues := make([]int, 100)
+	for i := range initValues {
+		initValues[i] = r.Intn(1000)
+	}
+	mmh := priorityqueue.InitMinMaxHeap[int](func(v1, v2 int) bool { return v1 < v2 }, initValues)
+	nodes := make([]*priorityqueue.MinMaxHeapNode[int], 0)
+	for i := 0; i < 100; i++ {
+		nodes = append(nodes, mmh.Push(r.Intn(1000)))
+	}
+	for i := 0; i < len(nodes); i += 2 {
+		mmh.Update(nodes[i], r.Intn(1000))
+	}
+	for i := 1; i < len(nodes); i += 4 {
+		mmh.Remove(nodes[i])
+	}
+
+	expValues := append([]int{}, initValues...)
+	for i, node := range nodes {
+		if i%4 != 1 {
+			expValues = append(expValues, node.GetValue())
+		}
+	}
+	sort.Ints(expValues)
+	// alternately pop from both ends
+	lo, hi := 0, len(expValues)-1
+	for lo <= hi {
+		if top, bottom := mmh.Top().GetValue(), mmh.Bottom().GetValue(); top != expValues[lo] || bottom != expValues[hi] {
+			t.Fatalf("Top() = %d, Bottom() = %d, expTop = %d, expBottom = %d", top, bottom, expValues[lo], expValues[hi])
+		}
+		if lo%2 == 0 {
+			mmh.PopTop()
+			lo++
+		} else {
+			mmh.PopBottom()
+			hi--
+		}
+		if mmh.Len() != int64(hi-lo+1) {
+			t.Fatalf("mmh.Len() = %d, exp = %d", mmh.Len(), hi-lo+1)
+		}
+	}
+}
+
+func TestMinMaxHeapEmpty(t *testing.T) {
+	mmh := priorityqueue.NewMinMaxHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	assertPanics := func(name string, f func()) {
+		defer func() {
+			if r := recover(); r != "min-max heap is empty" {
+				t.Errorf("%s on empty heap panicked with %v; exp = min-max heap is empty", name, r)
+			}
+		}()
+		f()
+	}
+	assertPanics("Top", func() { mmh.Top() })
+	assertPanics("Bottom", func() { mmh.Bottom() })
+	assertPanics("PopTop", func() { mmh.PopTop() })
+	assertPanics("PopBottom", func() { mmh.PopBottom() })
+}
@@ -0,0 +1,29 @@
+package priorityqueue
+
+import "cmp"
+
+// Returns instance of BinaryHeap where smaller values have higher priority (min-heap), ordered by cmp.Less.
+func NewMinHeap[V cmp.Ordered]() *BinaryHeap[V] {
+	return NewBinaryHeap[V](cmp.Less[V])
+}
+
+// Returns instance of BinaryHeap where greater values have higher priority (max-heap), ordered by cmp.Less.
+func NewMaxHeap[V cmp.Ordered]() *BinaryHeap[V] {
+	return NewBinaryHeap[V](func(v1, v2 V) bool {
+		return cmp.Less(v2, v1)
+	})
+}
+
+// Returns instance of min-heap holding initValues as NewMinHeap.
+// Takes O(n) time where n = len(initValues)
+func InitMinHeap[V cmp.Ordered](initValues []V) *BinaryHeap[V] {
+	return InitBinaryHeap[V](cmp.Less[V], initValues)
+}
+
+// Returns instance of max-heap holding initValues as NewMaxHeap.
+// Takes O(n) time where n = len(initValues)
+func InitMaxHeap[V cmp.Ordered](initValues []V) *BinaryHeap[V] {
+	return InitBinaryHeap[V](func(v1, v2 V) bool {
+		return cmp.Less(v2, v1)
+	}, initValues)
+}
@@ -0,0 +1,185 @@
+package priorityqueue
+
+// Pairing heap node
+type PairingHeapNode[V any] struct {
+	// prev points to parent if node is the leftmost child, otherwise to left sibling
+	child, sibling, prev *PairingHeapNode[V]
+	removed              bool
+	value                V
+}
+
+// Returns node's value
+func (phn *PairingHeapNode[V]) GetValue() V {
+	return phn.value
+}
+
+// Implements priorityqueue with Push, Pop, Top, Update, Remove and Meld operations.
+// Push, Meld and Update towards higher priority take O(1) time. Pop and Remove take O(log n) amortized time where n is number of values in the queue.
+type PairingHeap[V any] struct {
+	root         *PairingHeapNode[V]
+	priorityFunc func(v1, v2 V) bool
+	length       int64
+}
+
+// Returns instance of PairingHeap.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+func NewPairingHeap[V any](priorityFunc func(v1, v2 V) bool) *PairingHeap[V] {
+	return &PairingHeap[V]{
+		priorityFunc: priorityFunc,
+		length:       0,
+	}
+}
+
+// Insert value into the pairing heap and returns node's pointer to pushed value. Takes O(1) time.
+func (ph *PairingHeap[V]) Push(value V) *PairingHeapNode[V] {
+	newHeapNode := &PairingHeapNode[V]{
+		value: value,
+	}
+	ph.root = ph.meld(ph.root, newHeapNode)
+	ph.length++
+	return newHeapNode
+}
+
+// Returns node's pointer of highest priority value. Panics if pairing heap is empty. Takes O(1) time.
+func (ph *PairingHeap[V]) Top() *PairingHeapNode[V] {
+	if ph.root == nil {
+		panic("pairing heap is empty")
+	}
+	return ph.root
+}
+
+// Remove highest priority value and returns it. Panics if pairing heap is empty. Takes O(log n) amortized time where n is number of values in the queue.
+func (ph *PairingHeap[V]) Pop() V {
+	return ph.Remove(ph.Top())
+}
+
+// Deletes node in pairing heap. Has no effect if node is already removed. Takes O(log n) amortized time where n is number of values in the queue.
+func (ph *PairingHeap[V]) Remove(node *PairingHeapNode[V]) V {
+	if node.removed {
+		return node.value
+	}
+	if node == ph.root {
+		ph.root = ph.mergePairs(node.child)
+	} else {
+		ph.detach(node)
+		ph.root = ph.meld(ph.root, ph.mergePairs(node.child))
+	}
+	node.child = nil
+	node.removed = true
+	ph.length--
+	return node.value
+}
+
+// Update node's value to newValue. Has no effect if node is already removed.
+// Takes O(1) time if newValue does not have lower priority than node's value. Otherwise, takes O(log n) amortized time where n is number of values in the queue.
+func (ph *PairingHeap[V]) Update(node *PairingHeapNode[V], newValue V) {
+	if node.removed {
+		return
+	}
+	oldValue := node.value
+	node.value = newValue
+	if !ph.priorityFunc(oldValue, newValue) {
+		// priority did not decrease; node's subtree is still heap ordered
+		if node != ph.root {
+			ph.detach(node)
+			ph.root = ph.meld(ph.root, node)
+		}
+		return
+	}
+	// priority decreased; children may now have higher priority than node
+	children := ph.mergePairs(node.child)
+	node.child = nil
+	if node == ph.root {
+		ph.root = ph.meld(node, children)
+		return
+	}
+	ph.root = ph.meld(ph.root, children)
+}
+
+// Moves all values of other into the pairing heap, leaving other empty. Nodes of other remain valid and now belong to the pairing heap.
+// Takes O(1) time.
+func (ph *PairingHeap[V]) Meld(other *PairingHeap[V]) {
+	if other == ph {
+		return
+	}
+	ph.root = ph.meld(ph.root, other.root)
+	ph.length += other.length
+	other.root = nil
+	other.length = 0
+}
+
+// Returns number of values currently in the queue
+func (ph *PairingHeap[V]) Len() int64 {
+	return ph.length
+}
+
+// melds two detached trees and returns root of the resulting tree
+func (ph *PairingHeap[V]) meld(a, b *PairingHeapNode[V]) *PairingHeapNode[V] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if ph.priorityFunc(b.value, a.value) {
+		a, b = b, a
+	}
+	// b becomes leftmost child of a
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// cuts node's subtree from its parent
+func (ph *PairingHeap[V]) detach(node *PairingHeapNode[V]) {
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.prev = nil
+	node.sibling = nil
+}
+
+// melds list of sibling trees starting from first using two pass pairing and returns root of the resulting tree
+func (ph *PairingHeap[V]) mergePairs(first *PairingHeapNode[V]) *PairingHeapNode[V] {
+	if first == nil {
+		return nil
+	}
+	// first pass: meld pairs from left to right, linking melded trees in reverse order through sibling
+	var pairs *PairingHeapNode[V]
+	for first != nil {
+		a := first
+		b := a.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.prev = nil
+			b.sibling = nil
+		}
+		a.prev = nil
+		a.sibling = nil
+		melded := ph.meld(a, b)
+		melded.sibling = pairs
+		pairs = melded
+	}
+	// second pass: meld trees from right to left
+	root := pairs
+	rest := root.sibling
+	root.sibling = nil
+	for rest != nil {
+		next := rest.sibling
+		rest.sibling = nil
+		root = ph.meld(root, rest)
+		rest = next
+	}
+	root.prev = nil
+	return root
+}
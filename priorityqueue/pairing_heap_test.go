@@ -0,0 +1,36 @@
+package priorityqueue_test
+
+import (
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+	"github.com/storybehind/gocontainer/priorityqueue/priorityqueuetest"
+)
+
+func TestPairingHeap(t *testing.T) {
+	priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, *priorityqueue.PairingHeapNode[int]] {
+		return priorityqueue.NewPairingHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	})
+}
+
+func TestPairingHeapMeld(t *testing.T) {
+	ph1 := priorityqueue.NewPairingHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	ph2 := priorityqueue.NewPairingHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+	ph1.Push(5)
+	ph1.Push(3)
+	node8 := ph2.Push(8)
+	ph2.Push(1)
+	ph2.Push(4)
+
+	ph1.Meld(ph2)
+	if ph1.Len() != 5 || ph2.Len() != 0 {
+		t.Errorf("after meld; ph1.Len() = %d, ph2.Len() = %d, want 5 and 0", ph1.Len(), ph2.Len())
+	}
+	// nodes of melded heap remain valid
+	ph1.Update(node8, 2)
+	for _, expValue := range []int{1, 2, 3, 4, 5} {
+		if v := ph1.Pop(); v != expValue {
+			t.Errorf("ph1.Pop() = %d, expValue = %d", v, expValue)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package priorityqueue
+
+// Handle to a value pushed into a priority queue. Used to Update or Remove the value later.
+type PriorityQueueNode[V any] interface {
+	// Returns node's value
+	GetValue() V
+}
+
+// PriorityQueue interface. N is the type of node handle returned by the implementation.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true, where priorityFunc is provided on initialization.
+type PriorityQueue[V any, N PriorityQueueNode[V]] interface {
+	// Inserts value into the queue and returns node pointing to pushed value.
+	Push(value V) N
+	// Returns node pointing to highest priority value. Panics if queue is empty.
+	Top() N
+	// Removes highest priority value and returns it. Panics if queue is empty.
+	Pop() V
+	// Deletes node in the queue and returns its value. Has no effect if node is already removed.
+	Remove(node N) V
+	// Updates node's value to newValue. Has no effect if node is already removed.
+	Update(node N, newValue V)
+	// Returns number of values currently in the queue
+	Len() int64
+}
+
+type Tag int
+
+const (
+	BinaryHeapTag Tag = iota
+	PairingHeapTag
+	FibonacciHeapTag
+	MinMaxHeapTag
+)
+
+// Returns instance of PriorityQueue.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// By default, underlying container is BinaryHeap.
+func New[V any](priorityFunc func(v1, v2 V) bool) PriorityQueue[V, PriorityQueueNode[V]] {
+	return NewByTag[V](priorityFunc, BinaryHeapTag)
+}
+
+// Returns instance of PriorityQueue.
+// v1 has higher priority than v2 if priorityFunc(v1, v2) returns true
+// tag specifies underlying container. Can be BinaryHeapTag, PairingHeapTag, FibonacciHeapTag or MinMaxHeapTag.
+// Nodes passed to Update and Remove must be the ones returned by the same queue.
+func NewByTag[V any](priorityFunc func(v1, v2 V) bool, tag Tag) PriorityQueue[V, PriorityQueueNode[V]] {
+	switch tag {
+	case BinaryHeapTag:
+		return &priorityQueueAdapter[V, *BinaryHeapNode[V]]{
+			pq: NewBinaryHeap[V](priorityFunc),
+		}
+	case PairingHeapTag:
+		return &priorityQueueAdapter[V, *PairingHeapNode[V]]{
+			pq: NewPairingHeap[V](priorityFunc),
+		}
+	case FibonacciHeapTag:
+		return &priorityQueueAdapter[V, *FibonacciHeapNode[V]]{
+			pq: NewFibonacciHeap[V](priorityFunc),
+		}
+	case MinMaxHeapTag:
+		return &priorityQueueAdapter[V, *MinMaxHeapNode[V]]{
+			pq: NewMinMaxHeap[V](priorityFunc),
+		}
+	default:
+		panic("invalid tag type")
+	}
+}
+
+// Exposes PriorityQueue with node type N as PriorityQueue with PriorityQueueNode[V] node type
+type priorityQueueAdapter[V any, N PriorityQueueNode[V]] struct {
+	pq PriorityQueue[V, N]
+}
+
+func (pqa *priorityQueueAdapter[V, N]) Push(value V) PriorityQueueNode[V] {
+	return pqa.pq.Push(value)
+}
+
+func (pqa *priorityQueueAdapter[V, N]) Top() PriorityQueueNode[V] {
+	return pqa.pq.Top()
+}
+
+func (pqa *priorityQueueAdapter[V, N]) Pop() V {
+	return pqa.pq.Pop()
+}
+
+func (pqa *priorityQueueAdapter[V, N]) Remove(node PriorityQueueNode[V]) V {
+	return pqa.pq.Remove(node.(N))
+}
+
+func (pqa *priorityQueueAdapter[V, N]) Update(node PriorityQueueNode[V], newValue V) {
+	pqa.pq.Update(node.(N), newValue)
+}
+
+func (pqa *priorityQueueAdapter[V, N]) Len() int64 {
+	return pqa.pq.Len()
+}
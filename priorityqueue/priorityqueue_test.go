@@ -0,0 +1,19 @@
+package priorityqueue_test
+
+import (
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+	"github.com/storybehind/gocontainer/priorityqueue/priorityqueuetest"
+)
+
+func TestNewByTag(t *testing.T) {
+	for _, tag := range []priorityqueue.Tag{priorityqueue.BinaryHeapTag, priorityqueue.PairingHeapTag, priorityqueue.FibonacciHeapTag, priorityqueue.MinMaxHeapTag} {
+		priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, priorityqueue.PriorityQueueNode[int]] {
+			return priorityqueue.NewByTag[int](func(v1, v2 int) bool { return v1 < v2 }, tag)
+		})
+	}
+	priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, priorityqueue.PriorityQueueNode[int]] {
+		return priorityqueue.New[int](func(v1, v2 int) bool { return v1 < v2 })
+	})
+}
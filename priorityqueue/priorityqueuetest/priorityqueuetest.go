@@ -0,0 +1,189 @@
+// Package priorityqueuetest provides a conformance test suite for implementations of priorityqueue.PriorityQueue.
+//
+// An implementation passing Run behaves like BinaryHeap, PairingHeap, FibonacciHeap and MinMaxHeap for every method of PriorityQueue:
+//
+//	func TestMyHeap(t *testing.T) {
+//		priorityqueuetest.Run(t, func() priorityqueue.PriorityQueue[int, *MyHeapNode[int]] {
+//			return NewMyHeap[int](func(v1, v2 int) bool { return v1 < v2 })
+//		})
+//	}
+package priorityqueuetest
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+// Run tests the PriorityQueue contract as subtests of t, each on a new queue returned by newPriorityQueue:
+// a fixed sequence of Push, Top, Pop, Update and Remove, behaviour of empty queue, and random operation sequences checked against a sorted slice.
+// newPriorityQueue must return an empty min priority queue, i.e v1 has higher priority than v2 if v1 < v2
+func Run[N priorityqueue.PriorityQueueNode[int]](t *testing.T, newPriorityQueue func() priorityqueue.PriorityQueue[int, N]) {
+	t.Run("Sequence", func(t *testing.T) {
+		testSequence[N](t, newPriorityQueue())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		testEmpty[N](t, newPriorityQueue())
+	})
+	t.Run("Random", func(t *testing.T) {
+		testRandom[N](t, newPriorityQueue())
+	})
+}
+
+func testSequence[N priorityqueue.PriorityQueueNode[int]](t *testing.T, pq priorityqueue.PriorityQueue[int, N]) {
+	assertLen := func(expLen int64) {
+		if n := pq.Len(); n != expLen {
+			t.Errorf("pq.Len() = %d, want= %d", n, expLen)
+		}
+	}
+	assertTop := func(expTop int) {
+		if top := pq.Top().GetValue(); top != expTop {
+			t.Errorf("topFound = %v, topExpected = %v", top, expTop)
+		}
+	}
+	assertPop := func(expValue int) {
+		if v := pq.Pop(); v != expValue {
+			t.Errorf("found = %v, expected = %v", v, expValue)
+		}
+	}
+	assertRemove := func(node N, expValue int) {
+		if v := pq.Remove(node); v != expValue || node.GetValue() != expValue {
+			t.Errorf("removed = %v, node.GetValue() = %v, expected = %v", v, node.GetValue(), expValue)
+		}
+	}
+
+	assertLen(0)
+	node5 := pq.Push(5)
+	assertLen(1)
+	assertTop(5)
+	node2 := pq.Push(2)
+	assertTop(2)
+	node7 := pq.Push(7)
+	assertTop(2)
+	node51 := pq.Push(5)
+	assertLen(4)
+	assertTop(2)
+
+	// update towards higher priority
+	pq.Update(node5, 1)
+	assertLen(4)
+	assertTop(1)
+	// update towards lower priority
+	pq.Update(node5, 6)
+	assertTop(2)
+	pq.Update(node5, 1)
+
+	assertPop(1)
+	assertLen(3)
+	assertTop(2)
+
+	// removing and updating already removed node has no effect
+	assertRemove(node5, 1)
+	pq.Update(node5, 0)
+	assertLen(3)
+	assertTop(2)
+
+	assertRemove(node7, 7)
+	assertLen(2)
+	assertTop(2)
+
+	assertPop(2)
+	assertLen(1)
+	assertTop(5)
+
+	assertRemove(node51, 5)
+	assertLen(0)
+	assertRemove(node2, 2)
+	assertLen(0)
+}
+
+func testEmpty[N priorityqueue.PriorityQueueNode[int]](t *testing.T, pq priorityqueue.PriorityQueue[int, N]) {
+	assertPanics := func(name string, f func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s on empty queue; expected panic", name)
+			}
+		}()
+		f()
+	}
+	assertPanics("Top", func() { pq.Top() })
+	assertPanics("Pop", func() { pq.Pop() })
+	if pq.Len() != 0 {
+		t.Errorf("pq.Len() = %d, want= 0", pq.Len())
+	}
+}
+
+// runs random sequence of Push, Pop, Update and Remove operations on an empty min priority queue and compares it with a sorted slice
+func testRandom[N priorityqueue.PriorityQueueNode[int]](t *testing.T, pq priorityqueue.PriorityQueue[int, N]) {
+	r := rand.New(rand.NewSource(1))
+	nodes := make([]N, 0)
+	assertTop := func() {
+		if len(nodes) == 0 {
+			return
+		}
+		expTop := nodes[0].GetValue()
+		for _, node := range nodes {
+			if node.GetValue() < expTop {
+				expTop = node.GetValue()
+			}
+		}
+		if top := pq.Top().GetValue(); top != expTop {
+			t.Fatalf("pq.Top() = %d, expTop = %d", top, expTop)
+		}
+	}
+	removeNode := func(i int) {
+		nodes[i] = nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+	}
+
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4 || len(nodes) == 0:
+			nodes = append(nodes, pq.Push(r.Intn(1000)))
+		case op < 6:
+			// update towards both higher and lower priority
+			node := nodes[r.Intn(len(nodes))]
+			pq.Update(node, node.GetValue()+r.Intn(200)-100)
+		case op < 8:
+			i := r.Intn(len(nodes))
+			if v := pq.Remove(nodes[i]); v != nodes[i].GetValue() {
+				t.Fatalf("pq.Remove() = %d, node.GetValue() = %d", v, nodes[i].GetValue())
+			}
+			removeNode(i)
+		default:
+			top := pq.Top()
+			pq.Pop()
+			for i := range nodes {
+				if any(nodes[i]) == any(top) {
+					removeNode(i)
+					break
+				}
+			}
+		}
+		assertTop()
+		if pq.Len() != int64(len(nodes)) {
+			t.Fatalf("pq.Len() = %d, exp = %d", pq.Len(), len(nodes))
+		}
+	}
+
+	values := make([]int, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.GetValue()
+	}
+	sort.Ints(values)
+	for _, expValue := range values {
+		if v := pq.Pop(); v != expValue {
+			t.Fatalf("pq.Pop() = %d, expValue = %d", v, expValue)
+		}
+	}
+	// removing or updating already removed nodes has no effect
+	for _, node := range nodes {
+		pq.Remove(node)
+		pq.Update(node, 0)
+	}
+	if pq.Len() != 0 {
+		t.Fatalf("pq.Len() = %d, exp = 0", pq.Len())
+	}
+}
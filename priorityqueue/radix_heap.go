@@ -0,0 +1,147 @@
+package priorityqueue
+
+import "math/bits"
+
+// Radix heap node
+type RadixHeapNode[V any] struct {
+	// prev and next link node to other nodes of the same bucket
+	prev, next *RadixHeapNode[V]
+	radixHeap  *RadixHeap[V]
+	bucket     int
+	key        uint64
+	value      V
+}
+
+// Returns node's value
+func (rhn *RadixHeapNode[V]) GetValue() V {
+	return rhn.value
+}
+
+// Returns node's key
+func (rhn *RadixHeapNode[V]) GetKey() uint64 {
+	return rhn.key
+}
+
+// Implements monotone priorityqueue where value with lowest unsigned integer key is served first, with Push, Pop, Top, Update and Remove operations.
+// Keys are monotone: pushed or updated key must not be less than key of the last value returned by Top or Pop, which suits dijkstra with non negative weights.
+// Push, Update and Remove take O(1) time. Pop takes O(log C) amortized time where C is the largest key.
+type RadixHeap[V any] struct {
+	// bucket i holds nodes whose key differs from last in the bit i-1 and agrees with last in higher bits. bucket 0 holds nodes whose key equals last
+	buckets [65]*RadixHeapNode[V]
+	last    uint64
+	length  int64
+}
+
+// Returns instance of RadixHeap
+func NewRadixHeap[V any]() *RadixHeap[V] {
+	return &RadixHeap[V]{}
+}
+
+// Insert value with given key into the radix heap and returns node's pointer to pushed value.
+// Panics if key is less than key of the last value returned by Top or Pop. Takes O(1) time.
+func (rh *RadixHeap[V]) Push(key uint64, value V) *RadixHeapNode[V] {
+	if key < rh.last {
+		panic("key is less than key of the last served value")
+	}
+	newHeapNode := &RadixHeapNode[V]{
+		radixHeap: rh,
+		key:       key,
+		value:     value,
+	}
+	rh.add(newHeapNode)
+	rh.length++
+	return newHeapNode
+}
+
+// Returns node's pointer of value with lowest key. Panics if radix heap is empty. Takes O(log C) amortized time where C is the largest key.
+func (rh *RadixHeap[V]) Top() *RadixHeapNode[V] {
+	if rh.length == 0 {
+		panic("radix heap is empty")
+	}
+	if rh.buckets[0] == nil {
+		rh.redistribute()
+	}
+	return rh.buckets[0]
+}
+
+// Remove value with lowest key and returns it. Panics if radix heap is empty. Takes O(log C) amortized time where C is the largest key.
+func (rh *RadixHeap[V]) Pop() V {
+	return rh.Remove(rh.Top())
+}
+
+// Deletes node in radix heap. Has no effect if node is already removed or belongs to another heap. Takes O(1) time.
+func (rh *RadixHeap[V]) Remove(node *RadixHeapNode[V]) V {
+	if node.radixHeap != rh {
+		return node.value
+	}
+	rh.unlink(node)
+	node.radixHeap = nil
+	rh.length--
+	return node.value
+}
+
+// Update node's key to newKey. Has no effect if node is already removed or belongs to another heap.
+// Panics if newKey is less than key of the last value returned by Top or Pop. Takes O(1) time.
+func (rh *RadixHeap[V]) Update(node *RadixHeapNode[V], newKey uint64) {
+	if node.radixHeap != rh {
+		return
+	}
+	if newKey < rh.last {
+		panic("key is less than key of the last served value")
+	}
+	rh.unlink(node)
+	node.key = newKey
+	rh.add(node)
+}
+
+// Returns number of values currently in the queue
+func (rh *RadixHeap[V]) Len() int64 {
+	return rh.length
+}
+
+// adds node to the bucket of its key
+func (rh *RadixHeap[V]) add(node *RadixHeapNode[V]) {
+	bucket := bits.Len64(node.key ^ rh.last)
+	node.bucket = bucket
+	node.prev = nil
+	node.next = rh.buckets[bucket]
+	if node.next != nil {
+		node.next.prev = node
+	}
+	rh.buckets[bucket] = node
+}
+
+func (rh *RadixHeap[V]) unlink(node *RadixHeapNode[V]) {
+	if node.prev == nil {
+		rh.buckets[node.bucket] = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+}
+
+// moves lowest key of first non empty bucket to last and redistributes that bucket into lower buckets. bucket 0 must be empty.
+// Every node moves to a strictly lower bucket, so each node is redistributed at most 64 times.
+func (rh *RadixHeap[V]) redistribute() {
+	bucket := 1
+	for rh.buckets[bucket] == nil {
+		bucket++
+	}
+	head := rh.buckets[bucket]
+	rh.buckets[bucket] = nil
+	rh.last = head.key
+	for node := head.next; node != nil; node = node.next {
+		if node.key < rh.last {
+			rh.last = node.key
+		}
+	}
+	for node := head; node != nil; {
+		next := node.next
+		rh.add(node)
+		node = next
+	}
+}
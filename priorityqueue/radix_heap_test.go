@@ -0,0 +1,174 @@
+package priorityqueue_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+func TestRadixHeap(t *testing.T) {
+	rh := priorityqueue.NewRadixHeap[string]()
+	a := rh.Push(10, "a")
+	rh.Push(3, "b")
+	c := rh.Push(7, "c")
+	rh.Push(1<<40, "d")
+
+	if top := rh.Top(); top.GetKey() != 3 || top.GetValue() != "b" {
+		t.Errorf("Top() = (%d, %s), exp = (3, b)", top.GetKey(), top.GetValue())
+	}
+	rh.Update(a, 5)
+	rh.Remove(c)
+	rh.Remove(c)
+	for _, exp := range []string{"b", "a", "d"} {
+		if v := rh.Pop(); v != exp {
+			t.Errorf("Pop() = %s, exp = %s", v, exp)
+		}
+	}
+	if rh.Len() != 0 {
+		t.Errorf("Len() = %d, exp = 0", rh.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Push() of key less than last popped key; exp panic")
+		}
+	}()
+	rh.Push(1, "e")
+}
+
+func TestRadixHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rh := priorityqueue.NewRadixHeap[int]()
+	nodes := make(map[int]*priorityqueue.RadixHeapNode[int])
+	var last uint64
+	for id := 0; id < 10000; id++ {
+		switch op := r.Intn(10); {
+		case op < 5:
+			nodes[id] = rh.Push(last+uint64(r.Int63n(1<<uint(r.Intn(40)+1))), id)
+		case op < 7 && len(nodes) > 0:
+			// decrease key of some node, keeping it monotone
+			for _, node := range nodes {
+				rh.Update(node, last+(node.GetKey()-last)/2)
+				break
+			}
+		case op < 8 && len(nodes) > 0:
+			for removed, node := range nodes {
+				rh.Remove(node)
+				delete(nodes, removed)
+				break
+			}
+		case len(nodes) > 0:
+			keys := make([]uint64, 0, len(nodes))
+			for _, node := range nodes {
+				keys = append(keys, node.GetKey())
+			}
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			top := rh.Top()
+			if top.GetKey() != keys[0] {
+				t.Fatalf("Top() key = %d, exp = %d", top.GetKey(), keys[0])
+			}
+			if v := rh.Pop(); v != top.GetValue() {
+				t.Fatalf("Pop() = %d, exp = %d", v, top.GetValue())
+			}
+			delete(nodes, top.GetValue())
+			last = top.GetKey()
+		}
+		if rh.Len() != int64(len(nodes)) {
+			t.Fatalf("Len() = %d, exp = %d", rh.Len(), len(nodes))
+		}
+	}
+}
+
+type weightedEdge struct {
+	to     int
+	weight uint64
+}
+
+func randomGraph(r *rand.Rand, vertices, edges int) [][]weightedEdge {
+	graph := make([][]weightedEdge, vertices)
+	for i := 0; i < edges; i++ {
+		from := r.Intn(vertices)
+		graph[from] = append(graph[from], weightedEdge{to: r.Intn(vertices), weight: uint64(r.Intn(1000))})
+	}
+	return graph
+}
+
+func dijkstraRadixHeap(graph [][]weightedEdge) []uint64 {
+	dist := make([]uint64, len(graph))
+	nodes := make([]*priorityqueue.RadixHeapNode[int], len(graph))
+	done := make([]bool, len(graph))
+	rh := priorityqueue.NewRadixHeap[int]()
+	nodes[0] = rh.Push(0, 0)
+	for rh.Len() > 0 {
+		top := rh.Top()
+		u := rh.Pop()
+		dist[u], done[u] = top.GetKey(), true
+		for _, e := range graph[u] {
+			d := dist[u] + e.weight
+			switch {
+			case done[e.to]:
+			case nodes[e.to] == nil:
+				nodes[e.to] = rh.Push(d, e.to)
+			case d < nodes[e.to].GetKey():
+				rh.Update(nodes[e.to], d)
+			}
+		}
+	}
+	return dist
+}
+
+func dijkstraBinaryHeap(graph [][]weightedEdge) []uint64 {
+	type entry struct {
+		vertex int
+		dist   uint64
+	}
+	dist := make([]uint64, len(graph))
+	nodes := make([]*priorityqueue.BinaryHeapNode[entry], len(graph))
+	done := make([]bool, len(graph))
+	bh := priorityqueue.NewBinaryHeap[entry](func(e1, e2 entry) bool { return e1.dist < e2.dist })
+	nodes[0] = bh.Push(entry{0, 0})
+	for bh.Len() > 0 {
+		top := bh.Pop()
+		u := top.vertex
+		dist[u], done[u] = top.dist, true
+		for _, e := range graph[u] {
+			d := dist[u] + e.weight
+			switch {
+			case done[e.to]:
+			case nodes[e.to] == nil:
+				nodes[e.to] = bh.Push(entry{e.to, d})
+			case d < nodes[e.to].GetValue().dist:
+				bh.Update(nodes[e.to], entry{e.to, d})
+			}
+		}
+	}
+	return dist
+}
+
+func TestRadixHeapDijkstra(t *testing.T) {
+	graph := randomGraph(rand.New(rand.NewSource(1)), 1000, 5000)
+	if radix, binary := dijkstraRadixHeap(graph), dijkstraBinaryHeap(graph); fmt.Sprint(radix) != fmt.Sprint(binary) {
+		t.Errorf("distances found by radix heap differ from distances found by binary heap")
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	for _, vertices := range []int{1000, 100000} {
+		graph := randomGraph(rand.New(rand.NewSource(1)), vertices, vertices*8)
+		b.Run(fmt.Sprintf("RadixHeap/%d", vertices), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dijkstraRadixHeap(graph)
+			}
+		})
+		b.Run(fmt.Sprintf("BinaryHeap/%d", vertices), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dijkstraBinaryHeap(graph)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package priorityqueue
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timing wheel node
+type TimingWheelNode[V any] struct {
+	// wheel holding the node. nil if node is expired or cancelled
+	timingWheel *TimingWheel[V]
+	// prev and next link node to other nodes of the same bucket
+	prev, next *TimingWheelNode[V]
+	// bucket holding the node. nil if node is in overflow heap or is removed
+	bucket *timerBucket[V]
+	// node's pointer in overflow heap. nil if node is in a bucket or is removed
+	heapNode   *BinaryHeapNode[*TimingWheelNode[V]]
+	expiration int64
+	value      V
+}
+
+// Returns node's value
+func (twn *TimingWheelNode[V]) GetValue() V {
+	return twn.value
+}
+
+type timerBucket[V any] struct {
+	head, tail *TimingWheelNode[V]
+}
+
+// appends node at the tail so that nodes are taken in the order they are added
+func (tb *timerBucket[V]) add(node *TimingWheelNode[V]) {
+	node.bucket = tb
+	node.prev = tb.tail
+	node.next = nil
+	if tb.tail == nil {
+		tb.head = node
+	} else {
+		tb.tail.next = node
+	}
+	tb.tail = node
+}
+
+func (tb *timerBucket[V]) remove(node *TimingWheelNode[V]) {
+	if node.prev == nil {
+		tb.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		tb.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+	node.bucket = nil
+}
+
+// detaches every node of the bucket and returns the first one; nodes remain linked through next
+func (tb *timerBucket[V]) takeAll() *TimingWheelNode[V] {
+	head := tb.head
+	tb.head = nil
+	tb.tail = nil
+	return head
+}
+
+// Goroutine safe hierarchical timing wheel. Time is divided into ticks and level l of the wheel has wheelSize buckets each spanning wheelSize^l ticks.
+// Values due within wheelSize^levels ticks are kept in buckets and scheduled or cancelled in O(1) time.
+// Values due later are kept in an overflow BinaryHeap ordered by deadline and moved into the wheel once they come within its range.
+// Values never expire before their deadline and expire at most one tick after it.
+type TimingWheel[V any] struct {
+	mu        sync.Mutex
+	clock     Clock
+	tick      time.Duration
+	start     time.Time
+	wheelSize int64
+	// spans[l] is number of ticks covered by one bucket of level l. spans[levels] is range of the wheel
+	spans    []int64
+	buckets  [][]timerBucket[V]
+	overflow *BinaryHeap[*TimingWheelNode[V]]
+	// nodes whose deadline has been reached but are not yet returned by Expire
+	ready timerBucket[V]
+	// ticks elapsed since start that are already processed
+	current int64
+	// number of values in buckets of the wheel
+	inWheel int64
+	length  int64
+}
+
+// Returns instance of TimingWheel using system clock.
+// panics if tick is not positive, wheelSize is less than 2, levels is less than 1 or wheelSize^levels overflows int64
+func NewTimingWheel[V any](tick time.Duration, wheelSize, levels int) *TimingWheel[V] {
+	return NewTimingWheelWithClock[V](SystemClock(), tick, wheelSize, levels)
+}
+
+// Returns instance of TimingWheel using given clock.
+// panics if tick is not positive, wheelSize is less than 2, levels is less than 1 or wheelSize^levels overflows int64
+func NewTimingWheelWithClock[V any](clock Clock, tick time.Duration, wheelSize, levels int) *TimingWheel[V] {
+	if tick <= 0 {
+		panic("tick must be positive")
+	}
+	if wheelSize < 2 {
+		panic("wheelSize must be at least 2")
+	}
+	if levels < 1 {
+		panic("levels must be at least 1")
+	}
+	spans := make([]int64, levels+1)
+	spans[0] = 1
+	for l := 1; l <= levels; l++ {
+		if spans[l-1] > math.MaxInt64/int64(wheelSize) {
+			panic("wheelSize^levels overflows int64")
+		}
+		spans[l] = spans[l-1] * int64(wheelSize)
+	}
+	buckets := make([][]timerBucket[V], levels)
+	for l := range buckets {
+		buckets[l] = make([]timerBucket[V], wheelSize)
+	}
+	return &TimingWheel[V]{
+		clock:     clock,
+		tick:      tick,
+		start:     clock.Now(),
+		wheelSize: int64(wheelSize),
+		spans:     spans,
+		buckets:   buckets,
+		overflow: NewBinaryHeap[*TimingWheelNode[V]](func(v1, v2 *TimingWheelNode[V]) bool {
+			return v1.expiration < v2.expiration
+		}),
+	}
+}
+
+// Schedules value to expire at given time and returns node's pointer to it.
+// Takes O(1) time if deadline is within range of the wheel. Otherwise, takes O(log m) time where m is number of values in overflow heap.
+func (tw *TimingWheel[V]) Schedule(value V, at time.Time) *TimingWheelNode[V] {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	node := &TimingWheelNode[V]{
+		timingWheel: tw,
+		expiration:  tw.ticksUntil(at),
+		value:       value,
+	}
+	tw.place(node)
+	tw.length++
+	return node
+}
+
+// Removes node's value from the timing wheel. Returns false if value is already expired or cancelled or node is scheduled in another timing wheel.
+// Takes O(1) time if value is in the wheel. Otherwise, takes O(log m) time where m is number of values in overflow heap.
+func (tw *TimingWheel[V]) Cancel(node *TimingWheelNode[V]) bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if node.timingWheel != tw {
+		return false
+	}
+	node.timingWheel = nil
+	switch {
+	case node.bucket != nil:
+		if node.bucket != &tw.ready {
+			tw.inWheel--
+		}
+		node.bucket.remove(node)
+	case node.heapNode != nil:
+		tw.overflow.Remove(node.heapNode)
+		node.heapNode = nil
+	default:
+		return false
+	}
+	tw.length--
+	return true
+}
+
+// Advances the wheel to current time of the clock and returns values whose deadline is reached, ordered by tick of their deadline.
+// Takes O(t + e) time where t is number of elapsed ticks and e is number of expired values. Elapsed ticks are skipped while the wheel is empty.
+func (tw *TimingWheel[V]) Expire() []V {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	now := int64(tw.clock.Now().Sub(tw.start) / tw.tick)
+	expired := tw.collectOverdue()
+	for tw.current < now {
+		if tw.inWheel == 0 {
+			// nothing can expire before values of overflow heap come within range of the wheel
+			next := now
+			if tw.overflow.Len() > 0 {
+				boundary := tw.overflow.Top().GetValue().expiration / tw.spans[len(tw.buckets)] * tw.spans[len(tw.buckets)]
+				if boundary < next {
+					next = boundary
+				}
+			}
+			if next-1 > tw.current {
+				tw.current = next - 1
+			}
+		}
+		tw.current++
+		tw.advance()
+		expired = tw.collect(tw.ready.takeAll(), expired)
+	}
+	return expired
+}
+
+// Calls fn with expired values every tick until ctx is done. Returns ctx.Err().
+func (tw *TimingWheel[V]) Run(ctx context.Context, fn func(value V)) error {
+	for {
+		timer := tw.clock.NewTimer(tw.tick)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		for _, value := range tw.Expire() {
+			fn(value)
+		}
+	}
+}
+
+// Returns number of scheduled values that are not yet returned by Expire
+func (tw *TimingWheel[V]) Len() int64 {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.length
+}
+
+// Returns number of ticks from start to at, rounded up so that values never expire early
+func (tw *TimingWheel[V]) ticksUntil(at time.Time) int64 {
+	elapsed := at.Sub(tw.start)
+	ticks := int64(elapsed / tw.tick)
+	if elapsed%tw.tick > 0 {
+		ticks++
+	}
+	return ticks
+}
+
+// puts node in ready list, bucket of lowest level whose range contains node's expiration or overflow heap
+func (tw *TimingWheel[V]) place(node *TimingWheelNode[V]) {
+	if node.expiration <= tw.current {
+		tw.ready.add(node)
+		return
+	}
+	// node belongs to lowest level l where expiration and current differ only in digits below level l+1
+	for l := range tw.buckets {
+		if node.expiration/tw.spans[l+1] == tw.current/tw.spans[l+1] {
+			tw.buckets[l][node.expiration/tw.spans[l]%tw.wheelSize].add(node)
+			tw.inWheel++
+			return
+		}
+	}
+	node.heapNode = tw.overflow.Push(node)
+}
+
+// processes tick tw.current: pulls values coming within range of the wheel, cascades higher level buckets and moves due values to ready list
+func (tw *TimingWheel[V]) advance() {
+	levels := len(tw.buckets)
+	if tw.current%tw.spans[levels] == 0 {
+		for tw.overflow.Len() > 0 && tw.overflow.Top().GetValue().expiration/tw.spans[levels] == tw.current/tw.spans[levels] {
+			node := tw.overflow.Pop()
+			node.heapNode = nil
+			tw.place(node)
+		}
+	}
+	for l := levels - 1; l >= 0; l-- {
+		if tw.current%tw.spans[l] != 0 {
+			continue
+		}
+		bucket := &tw.buckets[l][tw.current/tw.spans[l]%tw.wheelSize]
+		for node := bucket.takeAll(); node != nil; {
+			next := node.next
+			node.prev, node.next, node.bucket = nil, nil, nil
+			tw.inWheel--
+			tw.place(node)
+			node = next
+		}
+	}
+}
+
+// appends values of detached list starting from head to expired and marks nodes as removed
+func (tw *TimingWheel[V]) collect(head *TimingWheelNode[V], expired []V) []V {
+	for node := head; node != nil; {
+		next := node.next
+		node.prev, node.next, node.bucket, node.timingWheel = nil, nil, nil, nil
+		expired = append(expired, node.value)
+		tw.length--
+		node = next
+	}
+	return expired
+}
+
+// returns values of ready list sorted by tick of their deadline. ready list holds values scheduled with a past deadline in the order they are scheduled
+func (tw *TimingWheel[V]) collectOverdue() []V {
+	var overdue []*TimingWheelNode[V]
+	for node := tw.ready.head; node != nil; node = node.next {
+		overdue = append(overdue, node)
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		return overdue[i].expiration < overdue[j].expiration
+	})
+	tw.ready.takeAll()
+	var expired []V
+	for _, node := range overdue {
+		node.prev, node.next, node.bucket, node.timingWheel = nil, nil, nil, nil
+		expired = append(expired, node.value)
+		tw.length--
+	}
+	return expired
+}
@@ -0,0 +1,187 @@
+package priorityqueue_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/storybehind/gocontainer/priorityqueue"
+)
+
+func TestTimingWheelRandom(t *testing.T) {
+	const tick = time.Millisecond
+	clock := newFakeClock()
+	start := clock.Now()
+	// range of the wheel is 16 ticks, so far deadlines go to overflow heap
+	tw := priorityqueue.NewTimingWheelWithClock[int](clock, tick, 4, 2)
+	r := rand.New(rand.NewSource(1))
+
+	nodes := make(map[int]*priorityqueue.TimingWheelNode[int])
+	deadlines := make(map[int]time.Time)
+	for id := 0; id < 5000; id++ {
+		switch op := r.Intn(10); {
+		case op < 6:
+			at := clock.Now().Add(time.Duration(r.Int63n(int64(100*tick))) - 2*tick)
+			nodes[id] = tw.Schedule(id, at)
+			deadlines[id] = at
+		case op < 8:
+			for cancelled := range nodes {
+				if !tw.Cancel(nodes[cancelled]) {
+					t.Fatalf("Cancel(%d) = false; exp = true", cancelled)
+				}
+				if tw.Cancel(nodes[cancelled]) {
+					t.Fatalf("Cancel(%d) twice = true; exp = false", cancelled)
+				}
+				delete(nodes, cancelled)
+				delete(deadlines, cancelled)
+				break
+			}
+		default:
+			clock.Advance(time.Duration(r.Int63n(int64(30 * tick))))
+			now := clock.Now()
+			nowTick := int64(now.Sub(start) / tick)
+			expired := tw.Expire()
+			expTicks := make([]int64, 0, len(expired))
+			for _, v := range expired {
+				at, ok := deadlines[v]
+				if !ok {
+					t.Fatalf("Expire() returned %d which is not scheduled", v)
+				}
+				if at.After(now) {
+					t.Fatalf("Expire() returned %d at %v before its deadline %v", v, now, at)
+				}
+				expTicks = append(expTicks, int64((at.Sub(start)+tick-1)/tick))
+				if tw.Cancel(nodes[v]) {
+					t.Fatalf("Cancel(%d) of expired value = true; exp = false", v)
+				}
+				delete(nodes, v)
+				delete(deadlines, v)
+			}
+			if !sort.SliceIsSorted(expTicks, func(i, j int) bool { return expTicks[i] < expTicks[j] }) {
+				t.Fatalf("Expire() returned values out of deadline order: %v", expTicks)
+			}
+			for v, at := range deadlines {
+				if int64((at.Sub(start)+tick-1)/tick) <= nowTick {
+					t.Fatalf("value %d with deadline %v did not expire at %v", v, at, now)
+				}
+			}
+		}
+		if tw.Len() != int64(len(nodes)) {
+			t.Fatalf("Len() = %d, exp = %d", tw.Len(), len(nodes))
+		}
+	}
+}
+
+func TestTimingWheelFarFuture(t *testing.T) {
+	clock := newFakeClock()
+	tw := priorityqueue.NewTimingWheelWithClock[string](clock, time.Second, 8, 2)
+	tw.Schedule("tomorrow", clock.Now().Add(24*time.Hour))
+	tw.Schedule("soon", clock.Now().Add(time.Second))
+
+	clock.Advance(24*time.Hour - time.Second)
+	if expired := tw.Expire(); len(expired) != 1 || expired[0] != "soon" {
+		t.Errorf("Expire() = %v, exp = [soon]", expired)
+	}
+	clock.Advance(time.Second)
+	if expired := tw.Expire(); len(expired) != 1 || expired[0] != "tomorrow" {
+		t.Errorf("Expire() = %v, exp = [tomorrow]", expired)
+	}
+}
+
+func TestTimingWheelRun(t *testing.T) {
+	clock := newFakeClock()
+	tw := priorityqueue.NewTimingWheelWithClock[int](clock, time.Second, 8, 2)
+	tw.Schedule(1, clock.Now().Add(3*time.Second))
+
+	fired := make(chan int, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- tw.Run(ctx, func(v int) { fired <- v })
+	}()
+	for received := false; !received; {
+		<-clock.created
+		select {
+		case v := <-fired:
+			if v != 1 {
+				t.Errorf("fired %d, exp = 1", v)
+			}
+			received = true
+		default:
+			clock.Advance(time.Second)
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() err = %v, exp = %v", err, context.Canceled)
+	}
+}
+
+func TestTimingWheelExpireOverdueOrder(t *testing.T) {
+	const tick = time.Millisecond
+	clock := newFakeClock()
+	start := clock.Now()
+	tw := priorityqueue.NewTimingWheelWithClock[string](clock, tick, 4, 2)
+
+	clock.Advance(5*tick + tick/2)
+	if expired := tw.Expire(); len(expired) != 0 {
+		t.Fatalf("Expire() = %v, exp = []", expired)
+	}
+	tw.Schedule("tick4", start.Add(3*tick+tick/2))
+	tw.Schedule("tick5", start.Add(5*tick))
+	tw.Schedule("tick2", start.Add(2*tick))
+	tw.Schedule("tick1", start.Add(tick))
+	tw.Schedule("tick4 again", start.Add(4*tick))
+	expired := tw.Expire()
+	exp := []string{"tick1", "tick2", "tick4", "tick4 again", "tick5"}
+	if len(expired) != len(exp) {
+		t.Fatalf("Expire() = %v, exp = %v", expired, exp)
+	}
+	for i := range exp {
+		if expired[i] != exp[i] {
+			t.Fatalf("Expire() = %v, exp = %v", expired, exp)
+		}
+	}
+}
+
+func TestTimingWheelCancelForeignOrExpiredNode(t *testing.T) {
+	clock := newFakeClock()
+	tw1 := priorityqueue.NewTimingWheelWithClock[int](clock, time.Second, 8, 2)
+	tw2 := priorityqueue.NewTimingWheelWithClock[int](clock, time.Second, 8, 2)
+	tw1.Schedule(1, clock.Now().Add(2*time.Second))
+	fired := tw1.Schedule(2, clock.Now().Add(time.Second))
+	foreign := tw2.Schedule(3, clock.Now().Add(2*time.Second))
+	farForeign := tw2.Schedule(4, clock.Now().Add(time.Hour))
+
+	if tw1.Cancel(foreign) || tw1.Cancel(farForeign) {
+		t.Fatalf("Cancel() of node from another timing wheel = true; exp = false")
+	}
+	if tw1.Len() != 2 || tw2.Len() != 2 {
+		t.Fatalf("Len() = %d, %d, exp = 2, 2", tw1.Len(), tw2.Len())
+	}
+
+	clock.Advance(time.Second)
+	if expired := tw1.Expire(); len(expired) != 1 || expired[0] != 2 {
+		t.Fatalf("Expire() = %v, exp = [2]", expired)
+	}
+	if tw1.Cancel(fired) || tw2.Cancel(fired) {
+		t.Fatalf("Cancel() of expired node = true; exp = false")
+	}
+	if tw1.Len() != 1 {
+		t.Fatalf("Len() = %d, exp = 1", tw1.Len())
+	}
+
+	clock.Advance(time.Second)
+	if expired := tw1.Expire(); len(expired) != 1 || expired[0] != 1 {
+		t.Errorf("Expire() = %v, exp = [1]", expired)
+	}
+	if expired := tw2.Expire(); len(expired) != 1 || expired[0] != 3 {
+		t.Errorf("Expire() = %v, exp = [3]", expired)
+	}
+	if !tw2.Cancel(farForeign) || tw2.Len() != 0 {
+		t.Errorf("Cancel() = false or Len() = %d; exp = true, 0", tw2.Len())
+	}
+}
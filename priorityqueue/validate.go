@@ -0,0 +1,35 @@
+package priorityqueue
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidHeap is wrapped by errors returned from Validate when heap violates one of its invariants
+var ErrInvalidHeap = errors.New("priorityqueue: invalid heap")
+
+// Validate checks that the heap satisfies its invariants: no node has higher priority than its parent (ties broken by insertion order in stable heap),
+// every node knows its position and owning heap, and Len() matches number of nodes.
+// It returns nil if heap is valid. Otherwise, an error wrapping ErrInvalidHeap describes the first violation found.
+// Takes O(n) time where n is number of values in the queue.
+func (bh *BinaryHeap[V]) Validate() error {
+	if int64(len(bh.nodes)) != bh.length+1 {
+		return fmt.Errorf("%w: Len() is %d but heap has %d nodes", ErrInvalidHeap, bh.length, len(bh.nodes)-1)
+	}
+	for i := int64(1); i <= bh.length; i++ {
+		node := bh.nodes[i]
+		if node == nil {
+			return fmt.Errorf("%w: node at index %d is nil", ErrInvalidHeap, i)
+		}
+		if node.index != i || node.binaryHeap != bh {
+			return fmt.Errorf("%w: node %v at index %d has index %d or belongs to another heap", ErrInvalidHeap, node.value, i, node.index)
+		}
+		if bh.stable && node.seq >= bh.nextSeq {
+			return fmt.Errorf("%w: node %v has sequence number %d beyond next sequence number %d", ErrInvalidHeap, node.value, node.seq, bh.nextSeq)
+		}
+		if parentIndex := bh.parent(i); parentIndex > 0 && bh.higher(i, parentIndex) {
+			return fmt.Errorf("%w: node %v has higher priority than its parent %v", ErrInvalidHeap, node.value, bh.nodes[parentIndex].value)
+		}
+	}
+	return nil
+}